@@ -0,0 +1,48 @@
+package got
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// annotations holds the metadata attached to constructors with Annotate.
+var annotations sync.Map
+
+// Annotate attaches static metadata to a constructor, for example an owner team or criticality,
+// and returns the constructor.
+// The metadata is reported by Container.EntriesWithMeta once the constructor is resolved.
+//
+//	var GetDB = got.Annotate(got.Using(newDB), map[string]string{"team": "storage"})
+func Annotate[C any](ct C, meta map[string]string) C {
+	annotations.Store(any(ct), maps.Clone(meta))
+	return ct
+}
+
+// EntryInfo describes a value cached in a container.
+type EntryInfo struct {
+	// Type is the type name of the value.
+	Type string
+	// Mocked reports whether the value was mocked.
+	Mocked bool
+	// Meta is the metadata attached to the constructor with Annotate.
+	Meta map[string]string
+}
+
+// EntriesWithMeta returns a description of each value cached in the container sorted by type name,
+// including the metadata attached to their constructors.
+func (c *Container) EntriesWithMeta() []EntryInfo {
+	var infos []EntryInfo
+	for key, e := range c.entries() {
+		info := EntryInfo{Type: e.name, Mocked: e.mock}
+		if meta, ok := annotations.Load(key); ok {
+			info.Meta = maps.Clone(meta.(map[string]string))
+		}
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b EntryInfo) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+	return infos
+}
@@ -0,0 +1,28 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+var GetAnnotatedPrinter = got.Annotate(got.Using(func(c *got.Container) *CapsPrinter {
+	return &CapsPrinter{}
+}), map[string]string{"team": "docs", "criticality": "low"})
+
+func TestEntriesWithMeta(t *testing.T) {
+	c := got.New()
+	GetAnnotatedPrinter.From(c)
+	GetCounter.From(c)
+
+	entries := c.EntriesWithMeta()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %v", entries)
+	}
+	if entries[0].Type != "*got_test.CapsPrinter" || entries[0].Meta["team"] != "docs" || entries[0].Meta["criticality"] != "low" {
+		t.Errorf("expected annotated printer entry, got %+v", entries[0])
+	}
+	if entries[1].Type != "*got_test.Counter" || entries[1].Meta != nil {
+		t.Errorf("expected counter entry without metadata, got %+v", entries[1])
+	}
+}
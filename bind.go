@@ -0,0 +1,68 @@
+package got
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// alias is implemented by constructors that resolve their value from another constructor
+// instead of caching their own.
+type alias[T any] interface {
+	resolve(c *Container, r *resolved) T
+}
+
+type bound[I, C any] struct{ ct Constructor[C] }
+
+func (b *bound[I, C]) New(c *Container) I { return any(b.ct.New(c)).(I) }
+
+func (b *bound[I, C]) From(c *Container) I { return From(c, b) }
+
+func (b *bound[I, C]) resolve(c *Container, r *resolved) I { return any(from(c, b.ct, r)).(I) }
+
+// Bind adapts a constructor of the concrete type C to a constructor of the interface I.
+// The returned constructor shares the cache entry of ct, so both return the same instance
+// and mocking ct also mocks the returned constructor.
+// Mocking the returned constructor overrides it without affecting ct.
+//
+// Bind panics if I is not an interface type or C does not implement I.
+//
+//	var GetPrinter = got.Bind[Printer](GetCapsPrinter)
+func Bind[I, C any](ct Constructor[C]) Constructor[I] {
+	iface, concrete := reflect.TypeFor[I](), reflect.TypeFor[C]()
+	if iface.Kind() != reflect.Interface || !concrete.Implements(iface) {
+		panic(fmt.Sprintf("got: cannot bind %s to %s", concrete, iface))
+	}
+	return &bound[I, C]{ct}
+}
+
+type projected[T, I any] struct {
+	ct Constructor[T]
+	as func(T) I
+}
+
+func (p *projected[T, I]) New(c *Container) I { return p.as(p.ct.New(c)) }
+
+func (p *projected[T, I]) From(c *Container) I { return From(c, p) }
+
+func (p *projected[T, I]) resolve(c *Container, r *resolved) I {
+	return p.as(from(c, p.ct, r))
+}
+
+// UsingAs creates a Constructor of T from fn and a Constructor of I that exposes the same value through as.
+// Both constructors share one construction, resolving either of them calls fn once per container.
+// Like Bind, mocking the constructor of T also changes the value of the constructor of I,
+// and mocking the constructor of I overrides it without affecting the constructor of T.
+//
+//	var GetService, GetHandler = got.UsingAs(NewService, func(s *Service) http.Handler { return s })
+func UsingAs[T, I any](fn func(*Container) T, as func(T) I) (Constructor[T], Constructor[I]) {
+	ct := Using(fn)
+	return ct, &projected[T, I]{ct, as}
+}
+
+// FromAs returns an instance of a constructor's value from the container like From,
+// asserted to the concrete type C.
+// It reports whether the value holds a C.
+func FromAs[I, C any](c *Container, ct Constructor[I]) (C, bool) {
+	v, ok := any(From(c, ct)).(C)
+	return v, ok
+}
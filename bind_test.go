@@ -0,0 +1,88 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+var GetCapsPrinter = got.Using(func(c *got.Container) *CapsPrinter {
+	return &CapsPrinter{}
+})
+
+func TestBind(t *testing.T) {
+	GetBoundPrinter := got.Bind[Printer](GetCapsPrinter)
+
+	c := got.New()
+	p := GetBoundPrinter.From(c)
+	if p != Printer(GetCapsPrinter.From(c)) || got.From(c, GetBoundPrinter) != p {
+		t.Error("expected bound constructor to share the concrete instance")
+	}
+
+	// mocking the concrete constructor mocks the bound constructor
+	mocked := &CapsPrinter{}
+	got.Mock(c, GetCapsPrinter, mocked)
+	if GetBoundPrinter.From(c) != Printer(mocked) {
+		t.Error("expected bound constructor to return the mocked concrete instance")
+	}
+
+	// mocking the bound constructor does not affect the concrete constructor
+	got.Mock[Printer](c, GetBoundPrinter, &MockPrinter{})
+	if _, ok := GetBoundPrinter.From(c).(*MockPrinter); !ok {
+		t.Error("expected mocked bound constructor")
+	}
+	if GetCapsPrinter.From(c) != mocked {
+		t.Error("expected concrete constructor to be unaffected")
+	}
+}
+
+func TestBindPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected bind of a type that does not implement the interface to panic")
+		}
+	}()
+	got.Bind[Printer](GetCounter)
+}
+
+func TestUsingAs(t *testing.T) {
+	var builds int
+	GetMainOffice, GetOfficePrinter := got.UsingAs(func(c *got.Container) *Office {
+		builds++
+		return &Office{Printer: GetPrinter.From(c)}
+	}, func(o *Office) Printer { return o.Printer })
+
+	c := got.New()
+	p := GetOfficePrinter.From(c)
+	if p != GetMainOffice.From(c).Printer || builds != 1 {
+		t.Errorf("expected one shared construction, got %d", builds)
+	}
+
+	// mocking the concrete constructor changes both
+	got.Mock(c, GetMainOffice, &Office{Printer: &MockPrinter{}})
+	if _, ok := GetOfficePrinter.From(c).(*MockPrinter); !ok {
+		t.Error("expected interface constructor to use the mocked concrete value")
+	}
+
+	// mocking the interface constructor does not affect the concrete constructor
+	got.Mock[Printer](c, GetOfficePrinter, &CapsPrinter{})
+	if _, ok := GetOfficePrinter.From(c).(*CapsPrinter); !ok {
+		t.Error("expected mocked interface constructor")
+	}
+	if _, ok := GetMainOffice.From(c).Printer.(*MockPrinter); !ok {
+		t.Error("expected concrete constructor to be unaffected")
+	}
+}
+
+func TestFromAs(t *testing.T) {
+	c := got.New()
+	if _, ok := got.FromAs[Printer, *CapsPrinter](c, GetPrinter); !ok {
+		t.Error("expected printer to be backed by *CapsPrinter")
+	}
+
+	mc := got.New()
+	got.Mock[Printer](mc, GetPrinter, &MockPrinter{})
+	if p, ok := got.FromAs[Printer, *CapsPrinter](mc, GetPrinter); ok || p != nil {
+		t.Error("expected mocked printer not to be a *CapsPrinter")
+	}
+}
@@ -0,0 +1,127 @@
+package got
+
+import "sync"
+
+// Cache stores the values of a container by constructor.
+// Implementations must be safe for concurrent use, *sync.Map implements Cache.
+//
+// Use NewWith to create a container with a custom Cache.
+type Cache interface {
+	Load(key any) (value any, ok bool)
+	Store(key, value any)
+	LoadOrStore(key, value any) (actual any, loaded bool)
+	Range(f func(key, value any) bool)
+	Delete(key any)
+}
+
+var _ Cache = (*sync.Map)(nil)
+
+// NewWith creates a new Container that stores its values in cache, configured with the given options.
+// Scopes and clones of the container use the default cache.
+//
+// Use NewWith to pick a cache suited to the workload, for example a map guarded by a sync.RWMutex
+// for containers that are resolved often and rarely modified.
+func NewWith(cache Cache, opts ...Option) *Container {
+	c := New(opts...)
+	c.custom = cache
+	if g, ok := cache.(interface{ Grow(n int) }); ok && c.opts.capacity > 0 {
+		g.Grow(c.opts.capacity)
+	}
+	return c
+}
+
+// cache returns the cache of the container.
+// Changes made through it are written to the tracer of the container, see Trace.
+func (c *Container) cache() Cache {
+	return (*cacheView)(c.base())
+}
+
+// cacheView is the cache of a container as seen by the container.
+type cacheView Container
+
+func (v *cacheView) inner() Cache {
+	if v.custom != nil {
+		return v.custom
+	}
+	return &v.syncMap
+}
+
+func (v *cacheView) Load(key any) (any, bool) { return v.inner().Load(key) }
+
+func (v *cacheView) Range(f func(key, value any) bool) { v.inner().Range(f) }
+
+func (v *cacheView) Store(key, value any) {
+	v.inner().Store(key, value)
+	v.traceStore(value)
+}
+
+func (v *cacheView) LoadOrStore(key, value any) (any, bool) {
+	actual, loaded := v.inner().LoadOrStore(key, value)
+	if !loaded {
+		v.traceStore(value)
+	}
+	return actual, loaded
+}
+
+func (v *cacheView) Delete(key any) {
+	if t := v.tracer.Load(); t != nil {
+		if e, ok := v.inner().Load(key); ok {
+			t.printf("reset constructor(%s)", e.(*entry).name)
+		}
+	}
+	v.inner().Delete(key)
+}
+
+func (v *cacheView) Clear() {
+	clearCache(v.inner())
+	if t := v.tracer.Load(); t != nil {
+		t.printf("clear")
+	}
+}
+
+func (v *cacheView) CompareAndDelete(key, old any) bool {
+	deleted := compareAndDelete(v.inner(), key, old)
+	if deleted {
+		if t := v.tracer.Load(); t != nil {
+			t.printf("reset constructor(%s)", old.(*entry).name)
+		}
+	}
+	return deleted
+}
+
+// traceStore writes the event of storing value to the tracer of the container, if it is traced.
+func (v *cacheView) traceStore(value any) {
+	if t := v.tracer.Load(); t != nil {
+		e := value.(*entry)
+		if e.mock {
+			t.printf("mock constructor(%s)", e.name)
+		} else {
+			t.printf("store constructor(%s)", e.name)
+		}
+	}
+}
+
+// clearCache deletes every value from the cache.
+func clearCache(cache Cache) {
+	if c, ok := cache.(interface{ Clear() }); ok {
+		c.Clear()
+		return
+	}
+	cache.Range(func(key, _ any) bool {
+		cache.Delete(key)
+		return true
+	})
+}
+
+// compareAndDelete deletes the value of key from the cache if it is old.
+// Caches without a CompareAndDelete method are checked and modified in two steps.
+func compareAndDelete(cache Cache, key, old any) bool {
+	if c, ok := cache.(interface{ CompareAndDelete(key, old any) bool }); ok {
+		return c.CompareAndDelete(key, old)
+	}
+	if v, ok := cache.Load(key); ok && v == old {
+		cache.Delete(key)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package got_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+// MapCache is a Cache backed by a map guarded by a sync.RWMutex.
+type MapCache struct {
+	mu sync.RWMutex
+	m  map[any]any
+}
+
+func (mc *MapCache) Load(key any) (any, bool) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	v, ok := mc.m[key]
+	return v, ok
+}
+
+func (mc *MapCache) Store(key, value any) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.m == nil {
+		mc.m = make(map[any]any)
+	}
+	mc.m[key] = value
+}
+
+func (mc *MapCache) LoadOrStore(key, value any) (any, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if v, ok := mc.m[key]; ok {
+		return v, true
+	}
+	if mc.m == nil {
+		mc.m = make(map[any]any)
+	}
+	mc.m[key] = value
+	return value, false
+}
+
+func (mc *MapCache) Range(f func(key, value any) bool) {
+	mc.mu.RLock()
+	m := make(map[any]any, len(mc.m))
+	for k, v := range mc.m {
+		m[k] = v
+	}
+	mc.mu.RUnlock()
+	for k, v := range m {
+		if !f(k, v) {
+			return
+		}
+	}
+}
+
+// Grow sizes the map for n values if it was not used yet.
+func (mc *MapCache) Grow(n int) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.m == nil {
+		mc.m = make(map[any]any, n)
+	}
+}
+
+func (mc *MapCache) Delete(key any) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	delete(mc.m, key)
+}
+
+func TestNewWith(t *testing.T) {
+	cache := &MapCache{}
+	c := got.NewWith(cache)
+	office := GetOffice.From(c)
+	if _, ok := cache.Load(GetOffice); !ok || GetOffice.From(c) != office {
+		t.Error("expected office to be cached in the custom cache")
+	}
+
+	got.Mock[Printer](c, GetPrinter, &MockPrinter{})
+	c.UnmockAll()
+	if _, ok := cache.Load(GetPrinter); ok {
+		t.Error("expected mock to be removed from the custom cache")
+	}
+
+	c.Clear()
+	if c.Len() != 0 || len(cache.m) != 0 {
+		t.Error("expected custom cache to be cleared")
+	}
+}
+
+func TestWithCapacity(t *testing.T) {
+	cache := &MapCache{}
+	c := got.NewWith(cache, got.WithCapacity(10))
+	if cache.m == nil {
+		t.Error("expected custom cache to be sized")
+	}
+	if GetOffice.From(c) != GetOffice.From(c) {
+		t.Error("expected office to be cached")
+	}
+
+	// the default cache ignores the capacity
+	c = got.New(got.WithCapacity(10))
+	if GetOffice.From(c) != GetOffice.From(c) {
+		t.Error("expected office to be cached")
+	}
+}
+
+// largeGraph is a graph of many independent constructors.
+var largeGraph = func() []got.Constructor[int] {
+	cts := make([]got.Constructor[int], 500)
+	for i := range cts {
+		cts[i] = got.Using(func(c *got.Container) int { return i })
+	}
+	return cts
+}()
+
+func BenchmarkWarmup(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []got.Option
+	}{
+		{"NoCapacity", nil},
+		{"Capacity", []got.Option{got.WithCapacity(len(largeGraph))}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				c := got.NewWith(&MapCache{}, bc.opts...)
+				for _, ct := range largeGraph {
+					ct.From(c)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCache(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		new  func() *got.Container
+	}{
+		{"SyncMap", func() *got.Container { return got.New() }},
+		{"MapCache", func() *got.Container { return got.NewWith(&MapCache{}) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			c := bc.new()
+			GetOffice.From(c)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					GetOffice.From(c)
+				}
+			})
+		})
+	}
+}
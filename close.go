@@ -0,0 +1,233 @@
+package got
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// closerList holds the functions registered with OnClose in a container.
+// It is allocated separately from the container so a cleanup can close it after the container is unreachable.
+type closerList struct {
+	mu  sync.Mutex
+	fns []func() error
+	// ctx is canceled when the container is closed, see Context.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// running holds the done channels of the constructions running in the background.
+	running map[chan struct{}]bool
+}
+
+// list returns the closer list of the container, allocating it on first use.
+func (c *Container) list() *closerList {
+	if c.closeTo != nil {
+		return c.closeTo
+	}
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closers == nil {
+		b.closers = &closerList{}
+	}
+	return b.closers
+}
+
+// addClosers registers the functions to be called when the container is closed.
+func (c *Container) addClosers(fns ...func() error) {
+	if len(fns) == 0 {
+		return
+	}
+	l := c.list()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fns = append(l.fns, fns...)
+}
+
+// close calls the functions in the list in reverse order and returns the joined errors.
+func (l *closerList) close() error {
+	l.mu.Lock()
+	fns := l.fns
+	l.fns = nil
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(fns) - 1; i >= 0; i-- {
+		errs = append(errs, fns[i]())
+	}
+	return errors.Join(errs...)
+}
+
+// context returns the context of the list, it is canceled by stop.
+func (l *closerList) context() context.Context {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.ctx == nil {
+		l.ctx, l.cancel = context.WithCancel(context.Background())
+	}
+	return l.ctx
+}
+
+// track registers a construction running in the background until it closes done.
+func (l *closerList) track(done chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.running == nil {
+		l.running = make(map[chan struct{}]bool)
+	}
+	l.running[done] = true
+}
+
+// untrack removes a construction registered with track.
+func (l *closerList) untrack(done chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.running, done)
+}
+
+// stop cancels the context of the list and waits at most d for the constructions running in the background to end.
+// The next call to context returns a new context.
+func (l *closerList) stop(d time.Duration) {
+	l.mu.Lock()
+	cancel := l.cancel
+	l.ctx, l.cancel = nil, nil
+	var running []chan struct{}
+	for done := range l.running {
+		running = append(running, done)
+	}
+	l.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	if d <= 0 || len(running) == 0 {
+		return
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for _, done := range running {
+		select {
+		case <-done:
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// Context returns a context for constructors that run in the background or wait on slow operations.
+// In a construction running in the background, such as one started by FromTimeout, the context is canceled
+// once the construction is abandoned or the container is closed. Otherwise it is canceled when the container is closed.
+// The container gets a new context once it is closed, so a container closed and used again is not canceled for good.
+//
+//	var GetDB = got.Using(func(c *got.Container) *DB {
+//		return Dial(c.Context(), "db:5432")
+//	})
+func (c *Container) Context() context.Context {
+	if c.res != nil && c.res.ctx != nil {
+		return c.res.ctx
+	}
+	return c.base().list().context()
+}
+
+// OnClose registers a function to be called when the container is closed.
+// Call OnClose from a constructor to release resources held by the value it builds.
+//
+// Functions registered by a constructor are registered with the container once the constructor returns,
+// so the functions of a value close before the functions of its dependencies.
+func OnClose(c *Container, fn func() error) {
+	if f := c.frame; f != nil {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !f.done {
+			f.closers = append(f.closers, fn)
+			return
+		}
+	}
+	c.addClosers(fn)
+}
+
+// UsingCloser creates a new Constructor from a function that returns a value and a function that releases it.
+// The release function is registered with OnClose when the value is built, a nil release function is ignored.
+func UsingCloser[T any](fn func(*Container) (T, func() error)) Constructor[T] {
+	return Using(func(c *Container) T {
+		v, release := fn(c)
+		if release != nil {
+			OnClose(c, release)
+		}
+		return v
+	})
+}
+
+// Close calls the functions registered with OnClose in reverse order and returns the joined errors.
+// Functions registered by constructors run in reverse order of construction completion,
+// and functions registered by the same constructor run in reverse order of registration.
+// Functions are called once, closing the container again only calls functions registered since.
+//
+// Close first cancels the context returned by Context, so constructions running in the background can stop.
+// It does not wait for them to end unless the container was created with WithCloseTimeout.
+//
+// Close does not clear the container cache.
+func (c *Container) Close() error {
+	l := c.list()
+	l.stop(c.base().opts.closeTimeout)
+	return l.close()
+}
+
+// EnableFinalizer registers a cleanup that closes the container if it is garbage collected without being closed,
+// as a safety net for forgotten teardown, for example in long-running test processes.
+// A warning with the number of functions called is logged with the log package, errors returned by them are logged too.
+//
+// Cleanups run at an unspecified time after the container becomes unreachable, on a separate goroutine,
+// and may not run at all before the program exits. A close function that references the container keeps it reachable,
+// so the cleanup never runs. Always prefer calling Close explicitly.
+func (c *Container) EnableFinalizer() {
+	b := c.base()
+	runtime.AddCleanup(b, func(l *closerList) {
+		l.mu.Lock()
+		n := len(l.fns)
+		l.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		log.Printf("got: container garbage collected without Close, calling %d close functions", n)
+		if err := l.close(); err != nil {
+			log.Printf("got: close: %v", err)
+		}
+	}, b.list())
+}
+
+// FromManaged returns an instance of a constructor's value from the container like From,
+// and registers the value to be closed when the container that caches it is closed.
+// The value is registered once no matter how many times FromManaged is called.
+// A value built again after the constructor is reset is registered again.
+func FromManaged[T io.Closer](c *Container, ct Constructor[T]) T {
+	v := From(c, ct)
+	// values are tracked by their cache entry, so a value built again gets a new entry.
+	var key any
+	e, b := c.loadOwner(ct)
+	if e != nil {
+		key = e
+	} else {
+		// values that are not cached, such as transient values, are tracked by constructor in the root container.
+		key = ct
+		for b = c.base(); b.parent != nil; b = b.parent {
+		}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.managed[key] {
+		if b.managed == nil {
+			b.managed = make(map[any]bool)
+		}
+		b.managed[key] = true
+		if b.closers == nil {
+			b.closers = &closerList{}
+		}
+		b.closers.mu.Lock()
+		b.closers.fns = append(b.closers.fns, v.Close)
+		b.closers.mu.Unlock()
+	}
+	return v
+}
@@ -0,0 +1,183 @@
+package got_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eriicafes/got"
+)
+
+type Resource struct {
+	name   string
+	closed *[]string
+}
+
+func (r *Resource) Close() error {
+	*r.closed = append(*r.closed, r.name)
+	return nil
+}
+
+func TestClose(t *testing.T) {
+	var closed []string
+	errClose := errors.New("close failed")
+
+	c := got.New()
+	got.OnClose(c, func() error {
+		closed = append(closed, "first")
+		return errClose
+	})
+	got.OnClose(c, func() error {
+		closed = append(closed, "second")
+		return nil
+	})
+
+	err := c.Close()
+	if !errors.Is(err, errClose) {
+		t.Errorf("expected close error, got %v", err)
+	}
+	if len(closed) != 2 || closed[0] != "second" || closed[1] != "first" {
+		t.Errorf("expected reverse order, got %v", closed)
+	}
+
+	// functions are only called once
+	if err := c.Close(); err != nil || len(closed) != 2 {
+		t.Errorf("expected second close to be a no-op, got %v, %v", err, closed)
+	}
+}
+
+func TestFromManaged(t *testing.T) {
+	var closed []string
+	GetDB := got.Using(func(c *got.Container) *Resource {
+		return &Resource{name: "db", closed: &closed}
+	})
+	GetCache := got.Using(func(c *got.Container) *Resource {
+		return &Resource{name: "cache", closed: &closed}
+	})
+
+	c := got.New()
+	db := got.FromManaged(c, GetDB)
+	got.FromManaged(c, GetCache)
+	if got.FromManaged(c, GetDB) != db {
+		t.Error("expected managed value to be cached")
+	}
+
+	if err := c.Close(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if len(closed) != 2 || closed[0] != "cache" || closed[1] != "db" {
+		t.Errorf("expected resources closed once in reverse order, got %v", closed)
+	}
+
+	// values built again after a reset are registered again
+	closed = nil
+	c = got.New()
+	got.FromManaged(c, GetDB)
+	got.Reset(c, GetDB)
+	got.FromManaged(c, GetDB)
+	got.ResetCascade(c, GetDB)
+	got.FromManaged(c, GetDB)
+	c.Close()
+	if len(closed) != 3 {
+		t.Errorf("expected every built value to be closed, got %v", closed)
+	}
+}
+
+func TestCloseOrder(t *testing.T) {
+	var closed []string
+	onClose := func(c *got.Container, name string) {
+		got.OnClose(c, func() error {
+			closed = append(closed, name)
+			return nil
+		})
+	}
+	GetLogger := got.Using(func(c *got.Container) *Counter {
+		onClose(c, "logger")
+		return &Counter{}
+	})
+	GetDB := got.Using(func(c *got.Container) *Counter {
+		onClose(c, "db pool")
+		onClose(c, "db conn")
+		return &Counter{}
+	})
+	GetServer := got.Using(func(c *got.Container) *Counter {
+		onClose(c, "listener")
+		GetDB.From(c)
+		GetLogger.From(c)
+		onClose(c, "server")
+		return &Counter{}
+	})
+
+	c := got.New()
+	onClose(c, "container")
+	GetServer.From(c)
+
+	if err := c.Close(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	expected := []string{"server", "listener", "logger", "db conn", "db pool", "container"}
+	if strings.Join(closed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected close order %v, got %v", expected, closed)
+	}
+}
+
+func TestUsingCloser(t *testing.T) {
+	var closed []string
+	GetDB := got.UsingCloser(func(c *got.Container) (*Resource, func() error) {
+		db := &Resource{name: "db", closed: &closed}
+		return db, db.Close
+	})
+	GetRepo := got.UsingCloser(func(c *got.Container) (*Resource, func() error) {
+		GetDB.From(c)
+		repo := &Resource{name: "repo", closed: &closed}
+		return repo, repo.Close
+	})
+
+	c := got.New()
+	GetRepo.From(c)
+	GetRepo.From(c)
+
+	if err := c.Close(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if strings.Join(closed, ",") != "repo,db" {
+		t.Errorf("expected dependents to close before dependencies, got %v", closed)
+	}
+}
+
+func TestEnableFinalizer(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	closed := make(chan struct{})
+	func() {
+		c := got.New()
+		c.EnableFinalizer()
+		got.OnClose(c, func() error {
+			close(closed)
+			return nil
+		})
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-closed:
+			// the warning is logged before close functions are called
+			if !strings.Contains(buf.String(), "garbage collected without Close") {
+				t.Errorf("expected warning to be logged, got %q", buf.String())
+			}
+			return
+		case <-deadline:
+			t.Fatal("expected close functions to run after the container is garbage collected")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
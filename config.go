@@ -0,0 +1,81 @@
+package got
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UsingValidatedConfig creates a new Constructor that validates the config built by the function.
+// An invalid config fails the construction, From panics with the validation error.
+//
+// The config is validated by each validate function in order, or by ValidateTags if none is given.
+// Validators from other packages can be plugged in through a method value, for example validator.New().Struct.
+func UsingValidatedConfig[C any](fn func(*Container) C, validate ...func(any) error) Constructor[C] {
+	if len(validate) == 0 {
+		validate = []func(any) error{ValidateTags}
+	}
+	return Using(func(c *Container) C {
+		config := fn(c)
+		for _, v := range validate {
+			if err := v(config); err != nil {
+				panic(fmt.Errorf("got: invalid config %T: %w", config, err))
+			}
+		}
+		return config
+	})
+}
+
+// ValidateTags validates the fields of a struct or pointer to struct using validate struct tags.
+// Nested structs are validated recursively, a struct reached again through a pointer is validated once.
+//
+// The only supported tag is validate:"required" which reports fields that hold their zero value.
+func ValidateTags(v any) error {
+	return validateStruct(reflect.ValueOf(v), "", make(map[visit]bool))
+}
+
+// visit is a struct reached through a pointer while validating.
+type visit struct {
+	ptr uintptr
+	t   reflect.Type
+}
+
+func validateStruct(v reflect.Value, prefix string, visited map[visit]bool) error {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		key := visit{v.Pointer(), v.Type()}
+		if visited[key] {
+			return nil
+		}
+		visited[key] = true
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var errs []error
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := prefix + field.Name
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			switch rule {
+			case "":
+			case "required":
+				if v.Field(i).IsZero() {
+					errs = append(errs, fmt.Errorf("field %s is required", name))
+				}
+			default:
+				errs = append(errs, fmt.Errorf("field %s has unknown validation rule %q", name, rule))
+			}
+		}
+		errs = append(errs, validateStruct(v.Field(i), name+".", visited))
+	}
+	return errors.Join(errs...)
+}
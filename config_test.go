@@ -0,0 +1,81 @@
+package got_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type DatabaseConfig struct {
+	Host string `validate:"required"`
+	Port int
+}
+
+type AppConfig struct {
+	Name     string `validate:"required"`
+	Database DatabaseConfig
+}
+
+// fromInvalid resolves the constructor and returns the error it panics with.
+func fromInvalid[T any](c *got.Container, ct got.Constructor[T]) (err error) {
+	defer func() { err, _ = recover().(error) }()
+	ct.From(c)
+	return nil
+}
+
+func TestUsingValidatedConfig(t *testing.T) {
+	GetConfig := got.UsingValidatedConfig(func(c *got.Container) *AppConfig {
+		return &AppConfig{Name: "app"}
+	})
+
+	c := got.New()
+	err := fromInvalid(c, GetConfig)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "field Database.Host is required") {
+		t.Errorf("expected field-level message, got %q", err)
+	}
+	if strings.Contains(err.Error(), "field Name") {
+		t.Errorf("expected only missing fields to be reported, got %q", err)
+	}
+
+	GetValidConfig := got.UsingValidatedConfig(func(c *got.Container) *AppConfig {
+		return &AppConfig{Name: "app", Database: DatabaseConfig{Host: "localhost"}}
+	})
+	if config := GetValidConfig.From(c); config.Name != "app" {
+		t.Errorf("expected valid config, got %v", config)
+	}
+}
+
+func TestUsingValidatedConfigCustomValidator(t *testing.T) {
+	errPort := errors.New("port must be set")
+	GetConfig := got.UsingValidatedConfig(func(c *got.Container) AppConfig {
+		return AppConfig{Name: "app", Database: DatabaseConfig{Host: "localhost"}}
+	}, got.ValidateTags, func(v any) error {
+		if v.(AppConfig).Database.Port == 0 {
+			return errPort
+		}
+		return nil
+	})
+
+	c := got.New()
+	if err := fromInvalid(c, GetConfig); !errors.Is(err, errPort) {
+		t.Errorf("expected custom validator error, got %v", err)
+	}
+}
+
+type Node struct {
+	Name string `validate:"required"`
+	Next *Node
+}
+
+func TestValidateTagsCycle(t *testing.T) {
+	node := &Node{}
+	node.Next = node
+	if err := got.ValidateTags(node); err == nil || strings.Count(err.Error(), "field Name is required") != 1 {
+		t.Errorf("expected self-referencing struct to be validated once, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package got
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ConstructorCtx is implemented by any type that has
+// a New method that accepts a context and a container and returns a value,
+// and a convenience From method that accepts a context and a container and returns the value from the container.
+//
+// Use UsingCtx to create a new ConstructorCtx.
+type ConstructorCtx[T any] interface {
+	New(context.Context, *Container) T
+	From(context.Context, *Container) (T, error)
+}
+
+type constructorCtx[T any] struct {
+	fn func(context.Context, *Container) T
+}
+
+func (ct *constructorCtx[T]) New(ctx context.Context, c *Container) T { return ct.fn(ctx, c) }
+
+func (ct *constructorCtx[T]) From(ctx context.Context, c *Container) (T, error) {
+	return FromCtx(ctx, c, ct)
+}
+
+// UsingCtx creates a new ConstructorCtx from a function that accepts a context and a container and returns a value.
+// The context governs only the first build of the value, use it for cancellation and deadlines during expensive initialization.
+func UsingCtx[T any](fn func(context.Context, *Container) T) ConstructorCtx[T] {
+	return &constructorCtx[T]{fn}
+}
+
+// FromCtx returns an instance of a constructor's value from the container like From,
+// passing ctx to the constructor's New method if the value is not cached.
+//
+// If ctx is done before or while the value is built, FromCtx returns the zero value and ctx.Err()
+// and the value is not cached, so a later call builds it again.
+// A cached value is returned regardless of ctx.
+//
+// In a scope created with ScopeFrom, ctx also inherits the deadline and cancellation of the scope's context.
+func FromCtx[T any](ctx context.Context, c *Container, ct ConstructorCtx[T]) (T, error) {
+	var zero T
+	if c.frame != nil {
+		c.frame.depend(ct)
+	}
+	if e, ok := c.load(ct); ok {
+		c.hit(e)
+		return as[T](e.value), nil
+	}
+	ctx, cancel := c.inherit(ctx)
+	defer cancel()
+	if p, err := c.delegate(ct); err != nil {
+		panic(fmt.Errorf("got: %s: %w", typeName[T](), err))
+	} else if p != nil {
+		return FromCtx(ctx, p, ct)
+	}
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	name := typeName[T]()
+	c.traceMiss(name)
+	e, err := c.once(ctx, ct, name, func() (*entry, error) {
+		v, deps := c.construct(ct, name, func(c *Container) any {
+			return ct.New(ctx, c)
+		})
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		return c.store(ct, &entry{value: as[T](v), name: name, deps: deps}), nil
+	})
+	if err != nil {
+		return zero, err
+	}
+	if e == nil {
+		return zero, ctx.Err()
+	}
+	return as[T](e.value), nil
+}
+
+// ScopeFrom creates a child container like Scope that carries ctx.
+// Context-aware constructors built in the scope or its own scopes with FromCtx
+// inherit the deadline and cancellation of ctx, so a construction that is still running
+// when the deadline passes fails with context.DeadlineExceeded.
+//
+// Use ScopeFrom to bound the constructions of a request without passing its context to every call.
+//
+//	scope := got.ScopeFrom(r.Context(), c)
+//	client, err := GetClient.From(context.Background(), scope)
+func ScopeFrom(ctx context.Context, c *Container) *Container {
+	s := c.Scope()
+	s.ctx = ctx
+	return s
+}
+
+// inherit returns ctx bound to the deadline and cancellation of the context
+// of the nearest scope created with ScopeFrom, and a function that releases it.
+func (c *Container) inherit(ctx context.Context) (context.Context, context.CancelFunc) {
+	var scope context.Context
+	for b := c.base(); b != nil && scope == nil; b = b.parent {
+		scope = b.ctx
+	}
+	if scope == nil {
+		return ctx, func() {}
+	}
+	var cancel context.CancelFunc
+	if deadline, ok := scope.Deadline(); ok {
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	// the deadline is set above so ctx reports context.DeadlineExceeded, only cancellation is forwarded.
+	forward := func() {
+		if errors.Is(scope.Err(), context.Canceled) {
+			cancel()
+		}
+	}
+	forward()
+	stop := context.AfterFunc(scope, forward)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,94 @@
+package got_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eriicafes/got"
+)
+
+func TestFromCtx(t *testing.T) {
+	type Client struct{ Printer Printer }
+	var builds int
+	GetClient := got.UsingCtx(func(ctx context.Context, c *got.Container) *Client {
+		builds++
+		return &Client{GetPrinter.From(c)}
+	})
+
+	c := got.New()
+	client, err := got.FromCtx(context.Background(), c, GetClient)
+	if err != nil || client == nil || client.Printer != GetPrinter.From(c) {
+		t.Fatalf("expected client with dependencies, got %v, %v", client, err)
+	}
+	client2, err := GetClient.From(context.Background(), c)
+	if err != nil || client2 != client || builds != 1 {
+		t.Error("expected client to be cached")
+	}
+
+	// cached values ignore the context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if client3, err := GetClient.From(ctx, c); err != nil || client3 != client {
+		t.Errorf("expected cached client with done context, got %v, %v", client3, err)
+	}
+}
+
+func TestFromCtxCancelled(t *testing.T) {
+	type Conn struct{}
+	var builds int
+	var cancelBuild context.CancelFunc
+	GetConn := got.UsingCtx(func(ctx context.Context, c *got.Container) *Conn {
+		builds++
+		if cancelBuild != nil {
+			cancelBuild()
+		}
+		return &Conn{}
+	})
+
+	c := got.New()
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := GetConn.From(done, c); !errors.Is(err, context.Canceled) || builds != 0 {
+		t.Errorf("expected done context to skip build, got %v after %d builds", err, builds)
+	}
+
+	// cancelled builds are not cached
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelBuild = cancel
+	if conn, err := GetConn.From(ctx, c); !errors.Is(err, context.Canceled) || conn != nil {
+		t.Errorf("expected cancelled error, got %v, %v", conn, err)
+	}
+	cancelBuild = nil
+	if conn, err := GetConn.From(context.Background(), c); err != nil || conn == nil || builds != 2 {
+		t.Errorf("expected retry to build conn, got %v, %v after %d builds", conn, err, builds)
+	}
+}
+
+func TestScopeFrom(t *testing.T) {
+	GetClient := got.UsingCtx(func(ctx context.Context, c *got.Container) *Counter {
+		<-ctx.Done()
+		return &Counter{}
+	})
+
+	c := got.New()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	scope := got.ScopeFrom(ctx, c)
+	if _, err := got.FromCtx(context.Background(), scope.Scope(), GetClient); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected inherited deadline to fail the construction, got %v", err)
+	}
+
+	// cancelling the scope context stops a construction mid-way
+	ctx, cancel = context.WithCancel(context.Background())
+	scope = got.ScopeFrom(ctx, c)
+	GetClient = got.UsingCtx(func(ctx context.Context, c *got.Container) *Counter {
+		cancel()
+		<-ctx.Done()
+		return &Counter{}
+	})
+	if _, err := GetClient.From(context.Background(), scope); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected scope cancellation to fail the construction, got %v", err)
+	}
+}
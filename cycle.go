@@ -0,0 +1,57 @@
+package got
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCircularDependency is the error From panics with when a constructor depends on itself,
+// directly or through its dependencies.
+// Cycles between constructions running on different goroutines are detected too,
+// as long as constructors resolve their dependencies through the container they are passed.
+var ErrCircularDependency = errors.New("circular dependency")
+
+// ErrMaxDepth is the error From panics with when a resolution nests more constructions than allowed by SetMaxDepth.
+var ErrMaxDepth = errors.New("maximum resolution depth exceeded")
+
+// SetMaxDepth limits the number of constructions nested in a single resolution in the container and its scopes to n,
+// so a pathologically deep graph fails with ErrMaxDepth naming the resolution chain instead of exhausting the stack.
+// Values already cached are not constructed and do not count towards the depth.
+// Containers are unlimited by default. A negative n removes the limit,
+// and zero makes the container use the limit of its nearest ancestor again.
+func (c *Container) SetMaxDepth(n int) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.maxDepth = n
+}
+
+// checkCycle panics with ErrCircularDependency if key named name is already being constructed in the resolution.
+func (c *Container) checkCycle(key any, name string) {
+	for f := c.frame; f != nil; f = f.parent {
+		if f.key == key {
+			panic(fmt.Errorf("got: %w: %s", ErrCircularDependency, c.frame.chain(name)))
+		}
+	}
+}
+
+// checkDepth panics with ErrMaxDepth if constructing key named name in the resolution exceeds
+// the maximum depth set with SetMaxDepth in the container or its nearest ancestor.
+func (c *Container) checkDepth(name string) {
+	var limit int
+	for b := c.base(); b != nil && limit == 0; b = b.parent {
+		b.mu.Lock()
+		limit = b.maxDepth
+		b.mu.Unlock()
+	}
+	if limit <= 0 {
+		return
+	}
+	depth := 1
+	for f := c.frame; f != nil; f = f.parent {
+		depth++
+	}
+	if depth > limit {
+		panic(fmt.Errorf("got: %w (%d): %s", ErrMaxDepth, limit, c.frame.chain(name)))
+	}
+}
@@ -0,0 +1,129 @@
+package got_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestCircularDependency(t *testing.T) {
+	type A struct{}
+	type B struct{}
+	var GetA got.Constructor[*A]
+	var GetB got.Constructor2[*B, error]
+	GetA = got.Using(func(c *got.Container) *A {
+		GetB.From(c)
+		return &A{}
+	})
+	GetB = got.Using2(func(c *got.Container) (*B, error) {
+		GetA.From(c)
+		return &B{}, nil
+	})
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, got.ErrCircularDependency) {
+			t.Fatalf("expected circular dependency panic, got %v", err)
+		}
+		expected := "got: circular dependency: *got_test.A -> (*got_test.B, error) -> *got_test.A"
+		if err.Error() != expected {
+			t.Errorf("expected %q, got %q", expected, err.Error())
+		}
+	}()
+	GetA.From(got.New())
+}
+
+func TestCircularDependencyConcurrent(t *testing.T) {
+	type X struct{}
+	type Y struct{}
+	xStarted, yStarted := make(chan struct{}), make(chan struct{})
+	startX, startY := sync.OnceFunc(func() { close(xStarted) }), sync.OnceFunc(func() { close(yStarted) })
+	var GetX got.Constructor[*X]
+	var GetY got.Constructor[*Y]
+	GetX = got.Using(func(c *got.Container) *X {
+		startX()
+		<-yStarted
+		GetY.From(c)
+		return &X{}
+	})
+	GetY = got.Using(func(c *got.Container) *Y {
+		startY()
+		<-xStarted
+		GetX.From(c)
+		return &Y{}
+	})
+
+	// each goroutine leads one construction and waits for the other one
+	c := got.New()
+	errs := make(chan any, 2)
+	resolve := func(from func()) {
+		defer func() { errs <- recover() }()
+		from()
+	}
+	go resolve(func() { GetX.From(c) })
+	go resolve(func() { GetY.From(c) })
+	for range 2 {
+		err, ok := (<-errs).(error)
+		if !ok || !errors.Is(err, got.ErrCircularDependency) {
+			t.Errorf("expected circular dependency panic, got %v", err)
+		}
+	}
+}
+
+func TestSetMaxDepth(t *testing.T) {
+	// each constructor depends on the next one, five levels deep
+	cts := make([]got.Constructor[int], 5)
+	for i := range cts {
+		cts[i] = got.Using(func(c *got.Container) int {
+			if i == len(cts)-1 {
+				return 0
+			}
+			return cts[i+1].From(c) + 1
+		})
+	}
+
+	c := got.New()
+	c.SetMaxDepth(3)
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, got.ErrMaxDepth) || !strings.Contains(err.Error(), "int -> int -> int -> int") {
+				t.Errorf("expected max depth error with the chain, got %v", err)
+			}
+		}()
+		cts[0].From(c)
+	}()
+
+	// scopes use the limit of their parent, cached values do not count
+	if v := cts[2].From(c.Scope()); v != 2 {
+		t.Errorf("expected resolution within the limit, got %d", v)
+	}
+	cts[2].From(c)
+	if v := cts[0].From(c); v != 4 {
+		t.Errorf("expected cached values not to count, got %d", v)
+	}
+
+	c = got.New()
+	c.SetMaxDepth(3)
+	s := c.Scope()
+	s.SetMaxDepth(-1)
+	if v := cts[0].From(s); v != 4 {
+		t.Errorf("expected unlimited scope, got %d", v)
+	}
+}
+
+func TestNoFalseCircularDependency(t *testing.T) {
+	c := got.New()
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			GetOffice.From(c.Scope())
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,37 @@
+package got
+
+import (
+	"fmt"
+	"log"
+)
+
+// NonSingleton is implemented by stateful values that are not meant to be shared.
+// In debug mode the container warns when such a value is returned from the cache.
+type NonSingleton interface {
+	NonSingleton()
+}
+
+// DebugMode enables or disables debug checks in the container and scopes created after the call.
+// In debug mode From warns when a value that implements NonSingleton is returned from the cache,
+// which usually means From was used where New or a Transient constructor was intended.
+// Each value is reported once, mocked values are not reported.
+//
+// Warnings are reported to the hooks registered with OnWarn, or logged with the log package if there are none.
+// When debug mode and tracing are disabled, a cache hit checks both with two atomic loads.
+func DebugMode(c *Container, on bool) {
+	c.base().debug.Store(on)
+}
+
+// checkShared warns if the entry holds a NonSingleton value returned from the cache for the first time.
+func (c *Container) checkShared(e *entry) {
+	if e.mock {
+		return
+	}
+	if _, ok := e.value.(NonSingleton); !ok || e.shared.Swap(true) {
+		return
+	}
+	msg := fmt.Sprintf("got: %s implements NonSingleton but was returned from the cache, use New or Transient for a fresh instance", e.name)
+	if !c.warn(msg) {
+		log.Print(msg)
+	}
+}
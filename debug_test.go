@@ -0,0 +1,48 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type Buffer struct{ data []byte }
+
+func (*Buffer) NonSingleton() {}
+
+func TestDebugMode(t *testing.T) {
+	GetBuffer := got.Using(func(c *got.Container) *Buffer { return &Buffer{} })
+
+	c := got.New()
+	var warnings []string
+	c.OnWarn(func(msg string) { warnings = append(warnings, msg) })
+
+	GetBuffer.From(c)
+	GetBuffer.From(c)
+	if len(warnings) != 0 {
+		t.Error("expected no warnings when debug mode is off")
+	}
+
+	got.DebugMode(c, true)
+	GetBuffer.From(c)
+	GetBuffer.From(c.Scope())
+	GetPrinter.From(c)
+	GetPrinter.From(c)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning for the shared non-singleton, got %v", warnings)
+	}
+	want := "got: *got_test.Buffer implements NonSingleton but was returned from the cache, use New or Transient for a fresh instance"
+	if warnings[0] != want {
+		t.Errorf("expected %q, got %q", want, warnings[0])
+	}
+
+	// mocked values are not reported
+	other := got.New()
+	other.OnWarn(func(msg string) { warnings = append(warnings, msg) })
+	got.DebugMode(other, true)
+	got.Mock(other, GetBuffer, &Buffer{})
+	GetBuffer.From(other)
+	if len(warnings) != 1 {
+		t.Errorf("expected no warning for mocked value, got %v", warnings)
+	}
+}
@@ -0,0 +1,39 @@
+package got
+
+import "slices"
+
+// decorate passes a value built by the constructor through the decorators registered for it in order.
+// Decorators registered in ancestors of the container run first.
+func decorate[T any](c *Container, ct Constructor[T], v T) T {
+	var decorators []any
+	for b := c.base(); b != nil; b = b.parent {
+		b.mu.Lock()
+		decorators = append(slices.Clone(b.decorators[ct]), decorators...)
+		b.mu.Unlock()
+	}
+	for _, d := range decorators {
+		v = d.(func(*Container, T) T)(c, v)
+	}
+	return v
+}
+
+// Decorate registers wrap to decorate values built by the constructor in the container before they are cached.
+// Decorators run in registration order, decorators registered in ancestors of the container run first.
+// Mocked values are not decorated.
+//
+// Decorate must be called before the constructor is resolved in the container,
+// values that are already cached are not decorated.
+func Decorate[T any](c *Container, ct Constructor[T], wrap func(*Container, T) T) {
+	addDecorator(c, ct, wrap)
+}
+
+// addDecorator registers a decorator for values built by the constructor.
+func addDecorator[T any](c *Container, ct Constructor[T], fn func(*Container, T) T) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.decorators == nil {
+		b.decorators = make(map[any][]any)
+	}
+	b.decorators[ct] = append(b.decorators[ct], fn)
+}
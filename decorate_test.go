@@ -0,0 +1,56 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type PrefixPrinter struct {
+	Printer
+	prefix string
+}
+
+func (p *PrefixPrinter) Print(s string) string {
+	return p.Printer.Print(p.prefix + s)
+}
+
+func TestDecorate(t *testing.T) {
+	c := got.New()
+	got.Decorate(c, GetPrinter, func(c *got.Container, p Printer) Printer {
+		return &PrefixPrinter{p, "a:"}
+	})
+	got.Decorate(c, GetPrinter, func(c *got.Container, p Printer) Printer {
+		return &PrefixPrinter{p, "b:"}
+	})
+
+	if s := GetPrinter.From(c).Print("hi"); s != "A:B:HI" {
+		t.Errorf("expected decorators to compose in registration order, got %q", s)
+	}
+	if GetPrinter.From(c) != GetPrinter.From(c) {
+		t.Error("expected decorated value to be cached")
+	}
+
+	// scopes run ancestor decorators first
+	parent := got.New()
+	got.Decorate(parent, GetPrinter, func(c *got.Container, p Printer) Printer {
+		return &PrefixPrinter{p, "a:"}
+	})
+	scope := parent.Scope()
+	got.Decorate(scope, GetPrinter, func(c *got.Container, p Printer) Printer {
+		return &PrefixPrinter{p, "b:"}
+	})
+	if s := GetPrinter.From(scope).Print("hi"); s != "A:B:HI" {
+		t.Errorf("expected ancestor decorators to run first, got %q", s)
+	}
+
+	// mocked values are not decorated
+	other := got.New()
+	got.Decorate(other, GetPrinter, func(c *got.Container, p Printer) Printer {
+		return &PrefixPrinter{p, "a:"}
+	})
+	got.Mock[Printer](other, GetPrinter, &MockPrinter{})
+	if _, ok := GetPrinter.From(other).(*MockPrinter); !ok {
+		t.Error("expected mocked value not to be decorated")
+	}
+}
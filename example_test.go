@@ -0,0 +1,32 @@
+package got_test
+
+import (
+	"fmt"
+
+	"github.com/eriicafes/got"
+)
+
+// Logging wraps the function of a constructor to log each time it builds a value.
+func Logging[T any](c *got.Container, ct got.Constructor[T]) {
+	fn, ok := got.FactoryOf(ct)
+	if !ok {
+		return
+	}
+	got.Override(c, ct, func(c *got.Container) T {
+		v := fn(c)
+		fmt.Printf("built %T\n", v)
+		return v
+	})
+}
+
+func ExampleFactoryOf() {
+	c := got.New()
+	Logging(c, GetOffice)
+
+	office := GetOffice.From(c)
+	GetOffice.From(c)
+	fmt.Println(office.Printer.Print("hello"))
+	// Output:
+	// built *got_test.Office
+	// HELLO
+}
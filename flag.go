@@ -0,0 +1,78 @@
+package got
+
+import "fmt"
+
+// flagChoice records the flag value used to build a flag constructor.
+type flagChoice struct {
+	value string
+	flag  func() string
+}
+
+// UsingFlag creates a new Constructor that resolves the implementation selected by the current flag value.
+// The flag is evaluated when the constructor is built, call RefreshFlags to pick up flag changes.
+// It panics if no implementation matches the flag value.
+func UsingFlag[T any](flag func() string, impls map[string]Constructor[T]) Constructor[T] {
+	var ct Constructor[T]
+	ct = Using(func(c *Container) T {
+		value := flag()
+		impl, ok := impls[value]
+		if !ok {
+			panic(fmt.Sprintf("got: no implementation for flag value %q", value))
+		}
+		b := c.base()
+		b.mu.Lock()
+		if b.flags == nil {
+			b.flags = make(map[any]flagChoice)
+		}
+		b.flags[ct] = flagChoice{value, flag}
+		b.mu.Unlock()
+		return impl.From(c)
+	})
+	return ct
+}
+
+// Switch creates a new Constructor that resolves the case selected by the selector.
+// The selector receives the container, for example to read configuration from a dependency,
+// and is evaluated once when the constructor is built, the selected value is cached like any other.
+// It panics if no case matches the selected key.
+//
+//	var GetStorage = got.Switch(func(c *got.Container) string { return GetConfig.From(c).Env }, map[string]got.Constructor[Storage]{
+//		"prod": GetS3Storage,
+//		"dev":  GetDiskStorage,
+//	})
+func Switch[T any](selector func(*Container) string, cases map[string]Constructor[T]) Constructor[T] {
+	return Using(func(c *Container) T {
+		key := selector(c)
+		ct, ok := cases[key]
+		if !ok {
+			panic(fmt.Sprintf("got: no case for %q", key))
+		}
+		return ct.From(c)
+	})
+}
+
+// RefreshFlags evaluates the flags of every flag constructor cached in the container
+// and drops the cached values of those whose flag value changed, so the next resolution uses the new implementation.
+//
+// Values already handed out keep referencing the previous implementation.
+func RefreshFlags(c *Container) {
+	b := c.base()
+	b.mu.Lock()
+	choices := make(map[any]flagChoice, len(b.flags))
+	for ct, choice := range b.flags {
+		choices[ct] = choice
+	}
+	b.mu.Unlock()
+
+	for ct, choice := range choices {
+		if choice.flag() == choice.value {
+			continue
+		}
+		b.mu.Lock()
+		if current, ok := b.flags[ct]; ok && current.value == choice.value {
+			delete(b.flags, ct)
+			b.cache().Delete(ct)
+		}
+		b.mu.Unlock()
+	}
+}
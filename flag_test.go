@@ -0,0 +1,79 @@
+package got_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestUsingFlag(t *testing.T) {
+	var flag atomic.Value
+	flag.Store("caps")
+	GetMockedPrinter := got.Using(func(c *got.Container) Printer {
+		return &MockPrinter{}
+	})
+	GetFlagPrinter := got.UsingFlag(func() string { return flag.Load().(string) }, map[string]got.Constructor[Printer]{
+		"caps": GetPrinter,
+		"mock": GetMockedPrinter,
+	})
+
+	c := got.New()
+	old := GetFlagPrinter.From(c)
+	if _, ok := old.(*CapsPrinter); !ok {
+		t.Errorf("expected caps printer, got %T", old)
+	}
+
+	// flag changes are not picked up until refreshed
+	flag.Store("mock")
+	if GetFlagPrinter.From(c) != old {
+		t.Error("expected cached printer before refresh")
+	}
+
+	got.RefreshFlags(c)
+	if _, ok := GetFlagPrinter.From(c).(*MockPrinter); !ok {
+		t.Errorf("expected mock printer after refresh, got %T", GetFlagPrinter.From(c))
+	}
+	if _, ok := old.(*CapsPrinter); !ok {
+		t.Error("expected previous references to keep the old printer")
+	}
+
+	// refreshing without flag changes keeps the cached value
+	current := GetFlagPrinter.From(c)
+	got.RefreshFlags(c)
+	if GetFlagPrinter.From(c) != current {
+		t.Error("expected cached printer when flag is unchanged")
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	env := "prod"
+	GetEnv := got.Using(func(c *got.Container) string { return env })
+	GetSwitchPrinter := got.Switch(func(c *got.Container) string { return GetEnv.From(c) }, map[string]got.Constructor[Printer]{
+		"prod": GetPrinter,
+		"dev": got.Using(func(c *got.Container) Printer {
+			return &MockPrinter{}
+		}),
+	})
+
+	c := got.New()
+	p := GetSwitchPrinter.From(c)
+	if p != GetPrinter.From(c) {
+		t.Error("expected prod case to be selected")
+	}
+	env = "dev"
+	if GetSwitchPrinter.From(c) != p {
+		t.Error("expected selected value to be cached")
+	}
+	if _, ok := GetSwitchPrinter.From(got.New()).(*MockPrinter); !ok {
+		t.Error("expected dev case in a new container")
+	}
+
+	env = "staging"
+	defer func() {
+		if r := recover(); r != `got: no case for "staging"` {
+			t.Errorf("expected panic for unmatched case, got %v", r)
+		}
+	}()
+	GetSwitchPrinter.From(got.New())
+}
@@ -1,19 +1,432 @@
 package got
 
-import "sync"
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // Container is a dependency injection container that caches constructor results.
 // It is safe for concurrent use by multiple goroutines.
 //
 // The zero Container is empty and ready for use.
 type Container struct {
-	cache sync.Map
+	syncMap sync.Map
+	custom  Cache
+	parent  *Container
+	opts    options
+	meta    any
+	// ctx is the context of a scope created with ScopeFrom.
+	ctx context.Context
+
+	// owner, res and frame are set on views of a container created for a single resolution.
+	// A view shares the cache of its owner and carries state for that resolution only.
+	owner *Container
+	res   *resolution
+	frame *frame
+	// closeTo, if set on a view, receives the close functions of constructions run through the view
+	// instead of the container.
+	closeTo *closerList
+
+	mu         sync.Mutex
+	decorators map[any][]any
+	factories  map[any]any
+	flags      map[any]flagChoice
+	allowed    map[any]bool
+	closers    *closerList
+	managed    map[any]bool
+	inflight   map[any]*call
+	observers  []func(ct any, dur time.Duration)
+	graphs     []*Graph
+	warners    []func(msg string)
+	before     []func(*Span)
+	after      []func(*Span)
+	fallback   func(reflect.Type) (any, bool)
+	providers  map[reflect.Type]func(*Container) any
+	effects    map[any]*sync.Once
+	maxDepth   int
+	debug      atomic.Bool
+	tracer     atomic.Pointer[tracer]
+}
+
+// call is a construction in progress that other callers can wait for.
+type call struct {
+	// done is closed when the construction ends, it is made by the first caller that waits.
+	done chan struct{}
+	// err is set by the leader when the construction failed in a way its waiters share.
+	err error
+	// waiting is the call the construction is blocked on, guarded by waits.
+	waiting *call
+}
+
+// waits guards the calls that constructions are blocked on, see wait.
+var waits sync.Mutex
+
+// join returns the construction in progress for key in the container,
+// or starts a new one and reports that the caller leads it.
+// The leader must call leave once the construction ends.
+func (c *Container) join(key any) (cl *call, leader bool) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if cl, ok := b.inflight[key]; ok {
+		if cl.done == nil {
+			cl.done = make(chan struct{})
+		}
+		return cl, false
+	}
+	if b.inflight == nil {
+		b.inflight = make(map[any]*call)
+	}
+	cl = &call{}
+	b.inflight[key] = cl
+	return cl, true
+}
+
+// leave ends the construction in progress for key and releases callers waiting for it.
+func (c *Container) leave(key any, cl *call) {
+	b := c.base()
+	b.mu.Lock()
+	delete(b.inflight, key)
+	done := cl.done
+	b.mu.Unlock()
+	if done != nil {
+		close(done)
+	}
+}
+
+// resolution holds state shared by every construction triggered by a single resolution.
+type resolution struct {
+	errs *errorList
+	// strict is set by FromStrict2 to stop the resolution at the first error of a two-value constructor.
+	strict bool
+	// ctx is the context of a construction running in the background, see Context.
+	ctx context.Context
+}
+
+// frame holds state for a single construction within a resolution.
+type frame struct {
+	parent *frame
+	key    any
+	name   string
+	// view is the container passed to the constructor, allocated with the frame.
+	view Container
+
+	mu      sync.Mutex
+	done    bool
+	closers []func() error
+	deps    []any
+	span    *Span
+}
+
+// chain describes the constructions leading to the frame followed by name, for example "A -> B -> C".
+func (f *frame) chain(name string) string {
+	return strings.Join(f.names(name), " -> ")
+}
+
+// names returns the names of the constructions leading to the frame followed by name.
+func (f *frame) names(name string) []string {
+	names := []string{name}
+	for ; f != nil; f = f.parent {
+		names = append(names, f.name)
+	}
+	slices.Reverse(names)
+	return names
+}
+
+// depend records a dependency resolved during the construction.
+func (f *frame) depend(key any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !slices.Contains(f.deps, key) {
+		f.deps = append(f.deps, key)
+	}
+}
+
+// base returns the container that owns the cache.
+func (c *Container) base() *Container {
+	if c.owner != nil {
+		return c.owner
+	}
+	return c
+}
+
+// entry is a value cached in the container.
+type entry struct {
+	value any
+	name  string
+	mock  bool
+	// weak is set if value is held by weak reference, see UsingWeak.
+	weak bool
+	deps []any
+	// usage counts the calls to From that return a mock installed with MockTracked.
+	usage *MockUsage
+	// shared is set once the value was returned from the cache in debug mode.
+	shared atomic.Bool
+}
+
+// values returns the values held by the entry.
+// The value of an entry held by weak reference is nil once it was reclaimed.
+func (e *entry) values() any {
+	if p, ok := e.value.(pair); ok {
+		return p.values()
+	}
+	v, _ := e.get()
+	return v
+}
+
+// get returns the value of the entry, and false if the entry held it by weak reference and it was reclaimed.
+func (e *entry) get() (any, bool) {
+	if w, ok := e.value.(interface{ strong() (any, bool) }); ok {
+		return w.strong()
+	}
+	return e.value, true
+}
+
+// load returns the cached entry for key from the container or its ancestors.
+func (c *Container) load(key any) (*entry, bool) {
+	e, _ := c.loadOwner(key)
+	return e, e != nil
+}
+
+// hit records that the cached entry e is returned by From.
+// It only checks whether there is anything to record, so it is cheap for plain entries.
+func (c *Container) hit(e *entry) {
+	if e.usage != nil || c.watched() {
+		c.recordHit(e)
+	}
+}
+
+// watched reports whether debug mode or tracing is enabled for the container.
+func (c *Container) watched() bool {
+	b := c.base()
+	return b.debug.Load() || b.tracer.Load() != nil
+}
+
+// recordHit records a hit of the cached entry e, see hit.
+func (c *Container) recordHit(e *entry) {
+	if c.base().debug.Load() {
+		c.checkShared(e)
+	}
+	if e.usage != nil {
+		e.usage.n.Add(1)
+	}
+	c.traceHit(e)
+}
+
+// loadOwner returns the cached entry for key and the container that caches it.
+func (c *Container) loadOwner(key any) (*entry, *Container) {
+	for b := c.base(); b != nil; b = b.parent {
+		if b.custom == nil {
+			// load from the default cache directly, calling it through the Cache interface costs a dynamic call.
+			if e, ok := b.syncMap.Load(key); ok {
+				return e.(*entry), b
+			}
+		} else if e, ok := b.custom.Load(key); ok {
+			return e.(*entry), b
+		}
+	}
+	return nil, nil
+}
+
+// store caches the entry for key in the container unless an entry is already cached,
+// and returns the cached entry.
+func (c *Container) store(key any, e *entry) *entry {
+	actual, _ := c.base().cache().LoadOrStore(key, e)
+	return actual.(*entry)
+}
+
+// view returns a view of the container for a resolution with the given state.
+func (c *Container) view(res *resolution, f *frame) *Container {
+	return &Container{owner: c.base(), res: res, frame: f}
+}
+
+// calls returns the constructions in progress that the frames of the resolution build.
+func (c *Container) calls() []*call {
+	var calls []*call
+	for f := c.frame; f != nil; f = f.parent {
+		b := f.view.owner
+		b.mu.Lock()
+		if cl := b.inflight[f.key]; cl != nil {
+			calls = append(calls, cl)
+		}
+		b.mu.Unlock()
+	}
+	return calls
 }
 
-// New creates a new Container.
+// once returns the entry for key cached in the container, or calls build to construct and cache it.
+// Only one construction of key runs in the container at a time,
+// callers that find a construction in progress wait for it to end and return the entry it cached.
+// If build returns an error waiting callers return the same error,
+// if the construction panics or caches nothing a waiting caller runs build itself.
+// Waiting stops with ctx.Err() when ctx is done.
+func (c *Container) once(ctx context.Context, key any, name string, build func() (*entry, error)) (*entry, error) {
+	c.checkCycle(key, name)
+	for {
+		cl, leader := c.join(key)
+		if !leader {
+			if err := c.wait(ctx, cl, name); err != nil {
+				return nil, err
+			}
+			if cl.err != nil {
+				return nil, cl.err
+			}
+			if e, ok := c.load(key); ok {
+				return e, nil
+			}
+			continue
+		}
+		return c.lead(key, cl, build)
+	}
+}
+
+// lead runs build for the construction cl of key led by the caller, unless an entry was cached meanwhile.
+func (c *Container) lead(key any, cl *call, build func() (*entry, error)) (*entry, error) {
+	defer c.leave(key, cl)
+	if e, ok := c.load(key); ok {
+		return e, nil
+	}
+	e, err := build()
+	cl.err = err
+	return e, err
+}
+
+// wait blocks until the construction in progress cl ends or ctx is done.
+// The constructions in the resolution are recorded as blocked on cl while waiting, which keeps the recorded waits acyclic,
+// so that a construction of another goroutine that cl is itself blocked on panics with ErrCircularDependency
+// instead of waiting forever.
+func (c *Container) wait(ctx context.Context, cl *call, name string) error {
+	calls := c.calls()
+	waits.Lock()
+	for next := cl; next != nil; next = next.waiting {
+		if slices.Contains(calls, next) {
+			waits.Unlock()
+			panic(fmt.Errorf("got: %w: %s", ErrCircularDependency, c.frame.chain(name)))
+		}
+	}
+	for _, own := range calls {
+		own.waiting = cl
+	}
+	waits.Unlock()
+	defer func() {
+		waits.Lock()
+		for _, own := range calls {
+			own.waiting = nil
+		}
+		waits.Unlock()
+	}()
+	select {
+	case <-cl.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// onceWait is once for callers that wait as long as needed and whose build always caches an entry.
+// If the construction it waits for fails with an error, the caller builds the entry itself.
+// build never returns an error.
+func (c *Container) onceWait(key any, name string, build func() (*entry, error)) *entry {
+	for {
+		if e, err := c.once(context.Background(), key, name, build); err == nil {
+			return e
+		}
+	}
+}
+
+// construct calls build with a view of the container for a new construction of key named name through the interceptors,
+// and returns the built value and the dependencies resolved during the construction.
+// Close functions registered during the construction are registered with the container once build returns.
+func (c *Container) construct(key any, name string, build func(*Container) any) (v any, deps []any) {
+	var built bool
+	c.checkCycle(key, name)
+	c.checkDepth(name)
+	if c.base().opts.strict {
+		panic(fmt.Errorf("got: %s: %w", name, ErrNotMocked))
+	}
+	if policy := c.base().opts.policy; policy != nil {
+		if err := policy(name); err != nil {
+			panic(fmt.Errorf("got: %s: %w", name, err))
+		}
+	}
+	if barrier := c.base().opts.barrier; barrier != nil {
+		barrier(name)
+	}
+	f := &frame{parent: c.frame, key: key, name: name}
+	f.view.owner, f.view.res, f.view.frame = c.base(), c.res, f
+	defer func() {
+		f.mu.Lock()
+		f.done = true
+		closers := f.closers
+		deps = f.deps
+		f.mu.Unlock()
+		c.addClosers(closers...)
+		if built {
+			c.record(key, name, deps)
+		}
+	}()
+	observers := c.resolveObservers()
+	var start time.Time
+	if len(observers) > 0 {
+		start = time.Now()
+	}
+	if interceptors := c.base().opts.interceptors; len(interceptors) > 0 {
+		resolve := func(r Resolution) any { return build(r.Container) }
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			resolve = interceptors[i](resolve)
+		}
+		v = resolve(Resolution{Container: &f.view, Constructor: key, Type: name})
+	} else {
+		v = build(&f.view)
+	}
+	built = true
+	if len(observers) > 0 {
+		dur := time.Since(start)
+		for _, fn := range observers {
+			fn(key, dur)
+		}
+	}
+	return v, nil
+}
+
+// as asserts v to the type T, a nil v returns the zero value of T.
+func as[T any](v any) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return v.(T)
+}
+
+// New creates a new Container configured with the given options.
 // While the zero value of Container is ready to use, New() is provided for API clarity.
-func New() *Container {
-	return &Container{}
+func New(opts ...Option) *Container {
+	c := &Container{}
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+	return c
+}
+
+// typeName returns the name of the type T.
+func typeName[T any]() string {
+	return reflect.TypeFor[T]().String()
+}
+
+// typeName2 returns the name of the types T and U.
+func typeName2[T, U any]() string {
+	return "(" + typeName[T]() + ", " + typeName[U]() + ")"
+}
+
+// typeName3 returns the name of the types T, U and V.
+func typeName3[T, U, V any]() string {
+	return "(" + typeName[T]() + ", " + typeName[U]() + ", " + typeName[V]() + ")"
 }
 
 // Constructor is implemented by any type that has
@@ -32,6 +445,8 @@ func (ct *constructor[T]) New(c *Container) T { return ct.fn(c) }
 
 func (ct *constructor[T]) From(c *Container) T { return From(c, ct) }
 
+func (ct *constructor[T]) Unwrap() func(*Container) T { return ct.fn }
+
 // Using creates a new Constructor from a function that accepts a container and returns a value.
 func Using[T any](fn func(*Container) T) Constructor[T] {
 	return &constructor[T]{fn}
@@ -40,16 +455,94 @@ func Using[T any](fn func(*Container) T) Constructor[T] {
 // From returns an instance of a constructor's value from the container.
 // The constructor's New method is called the first time and the return value is cached.
 // Future calls will return the cached value.
+//
+// New runs once even when many goroutines call From at the same time,
+// the other goroutines wait for it to return and receive the same value.
+//
+// The value is cached only after New returns normally.
+// If New panics nothing is cached and the next call runs New again.
 func From[T any](c *Container, ct Constructor[T]) T {
-	if v, ok := c.cache.Load(ct); ok {
-		return v.(T)
+	return from(c, ct, nil)
+}
+
+// FromReport returns an instance of a constructor's value from the container like From,
+// and reports whether this call built the value.
+// When many goroutines resolve the constructor at the same time only the one that builds the value reports true.
+// A value built by a transient constructor is always reported as built.
+//
+// Use FromReport to tell cold resolutions from warm ones, or to detect that a value was rebuilt after a Reset.
+func FromReport[T any](c *Container, ct Constructor[T]) (T, bool) {
+	var r resolved
+	v := from(c, ct, &r)
+	return v, r.built
+}
+
+// resolved describes how a call to from resolved a value.
+type resolved struct {
+	// built is set when the call built the value.
+	built bool
+	// e is the entry holding the value, nil for a value that is not cached.
+	e *entry
+}
+
+// from returns the value of the constructor from the container
+// and describes in r, if not nil, how it was resolved.
+func from[T any](c *Container, ct Constructor[T], r *resolved) T {
+	if c.frame != nil {
+		c.frame.depend(ct)
 	}
-	v := ct.New(c)
-	actual, loaded := c.cache.LoadOrStore(ct, v)
-	if loaded {
-		return actual.(T)
+	if e, ok := c.load(ct); ok && !e.weak {
+		// hit is too large to be inlined, check for anything to record here to keep cache hits cheap.
+		if e.usage != nil || c.watched() {
+			c.recordHit(e)
+		}
+		if r != nil {
+			r.e = e
+		}
+		return as[T](e.value)
 	}
-	return v
+	if w, ok := ct.(weakly[T]); ok {
+		return w.resolveWeak(c, r)
+	}
+	if a, ok := ct.(alias[T]); ok {
+		return a.resolve(c, r)
+	}
+	if p, err := c.delegate(ct); err != nil {
+		panic(fmt.Errorf("got: %s: %w", typeName[T](), err))
+	} else if p != nil {
+		return from(p, ct, r)
+	}
+	name := typeName[T]()
+	c.traceMiss(name)
+	build := func(c *Container) any { return newDecorated(c, ct) }
+	if _, ok := ct.(*transient[T]); ok {
+		v, _ := c.construct(ct, name, build)
+		if r != nil {
+			r.built = true
+		}
+		return as[T](v)
+	}
+	e := c.onceWait(ct, name, func() (*entry, error) {
+		v, deps := c.construct(ct, name, build)
+		e := &entry{value: as[T](v), name: name, deps: deps}
+		stored := c.store(ct, e)
+		if r != nil && stored == e {
+			r.built = true
+		}
+		return stored, nil
+	})
+	if r != nil {
+		r.e = e
+	}
+	return as[T](e.value)
+}
+
+// Value creates a new Constructor whose New method returns v.
+// Use Value for plain data such as configuration, the value can be mocked like any other constructor.
+//
+//	var GetPort = got.Value(8080)
+func Value[T any](v T) Constructor[T] {
+	return Using(func(*Container) T { return v })
 }
 
 // Constructor2 is implemented by any type that has
@@ -78,21 +571,58 @@ func Using2[T, U any](fn func(*Container) (T, U)) Constructor2[T, U] {
 }
 
 // From2 returns an instance of a constructor's value from the container.
+// Like From, New runs once even when many goroutines call From2 at the same time.
 // The constructor's New method is called the first time and the return values are cached.
 // Future calls will return the cached values.
+//
+// The values are cached only after New returns normally.
+// If New panics nothing is cached and the next call runs New again.
 func From2[T, U any](c *Container, ct Constructor2[T, U]) (T, U) {
-	if v, ok := c.cache.Load(ct); ok {
-		f2 := v.(from2[T, U])
-		return f2.v1, f2.v2
+	return resolve2(c, ct, ct.New)
+}
+
+// resolve2 returns the values of the constructor from the container like From2, building them with build.
+func resolve2[T, U any](c *Container, ct Constructor2[T, U], build func(*Container) (T, U)) (T, U) {
+	if c.frame != nil {
+		c.frame.depend(ct)
 	}
-	v1, v2 := ct.New(c)
-	val := from2[T, U]{v1, v2}
-	actual, loaded := c.cache.LoadOrStore(ct, val)
-	if loaded {
-		f2 := actual.(from2[T, U])
+	if e, ok := c.load(ct); ok {
+		c.traceHit(e)
+		f2 := e.value.(from2[T, U])
+		c.shortCircuit(f2.v2)
 		return f2.v1, f2.v2
 	}
-	return v1, v2
+	if p, err := c.delegate(ct); err != nil {
+		panic(fmt.Errorf("got: %s: %w", typeName2[T, U](), err))
+	} else if p != nil {
+		return resolve2(p, ct, build)
+	}
+	name := typeName2[T, U]()
+	c.traceMiss(name)
+	e := c.onceWait(ct, name, func() (*entry, error) {
+		v, deps := c.construct(ct, name, func(c *Container) any {
+			v1, v2 := build(c)
+			return from2[T, U]{v1, v2}
+		})
+		if c.base().opts.errorChain {
+			v = chainError(c, v.(from2[T, U]))
+		}
+		if c.res != nil && c.res.errs != nil {
+			if err, ok := any(v.(from2[T, U]).v2).(error); ok && err != nil {
+				c.res.errs.add(err)
+			}
+		}
+		e := &entry{value: v, name: name, deps: deps}
+		if _, ok := any(ct).(retrier); ok {
+			if err, _ := any(v.(from2[T, U]).v2).(error); err != nil {
+				return e, nil
+			}
+		}
+		return c.store(ct, e), nil
+	})
+	f2 := e.value.(from2[T, U])
+	c.shortCircuit(f2.v2)
+	return f2.v1, f2.v2
 }
 
 type from2[T, U any] struct {
@@ -100,12 +630,9 @@ type from2[T, U any] struct {
 	v2 U
 }
 
-// Mock modifies the container cache to return a mocked instance for the constructor.
-func Mock[T any](c *Container, ct Constructor[T], v T) {
-	c.cache.Store(ct, v)
+// pair is implemented by cached values that hold multiple values.
+type pair interface {
+	values() any
 }
 
-// Mock2 modifies the container cache to return a mocked instance for the constructor.
-func Mock2[T, U any](c *Container, ct Constructor2[T, U], v1 T, v2 U) {
-	c.cache.Store(ct, from2[T, U]{v1, v2})
-}
+func (f2 from2[T, U]) values() any { return []any{f2.v1, f2.v2} }
@@ -370,6 +370,139 @@ func TestConcurrency(t *testing.T) {
 	}
 }
 
+func TestConcurrencySingleConstruction(t *testing.T) {
+	var builds atomic.Int32
+	release := make(chan struct{})
+	GetSlowCounter := got.Using(func(c *got.Container) *Counter {
+		builds.Add(1)
+		<-release
+		return &Counter{}
+	})
+	GetSlowOffice := got.Using2(func(c *got.Container) (*Office, error) {
+		builds.Add(1)
+		<-release
+		return &Office{}, nil
+	})
+
+	c := got.New()
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			GetSlowCounter.From(c)
+		}()
+		go func() {
+			defer wg.Done()
+			GetSlowOffice.From(c)
+		}()
+	}
+	close(release)
+	wg.Wait()
+	if n := builds.Load(); n != 2 {
+		t.Errorf("expected each constructor to run once, ran %d times", n)
+	}
+}
+
+func TestConcurrencyPanicRetry(t *testing.T) {
+	var builds atomic.Int32
+	release := make(chan struct{})
+	GetFlakyCounter := got.Using(func(c *got.Container) *Counter {
+		if builds.Add(1) == 1 {
+			<-release
+			panic("first build fails")
+		}
+		return &Counter{}
+	})
+
+	c := got.New()
+	var wg sync.WaitGroup
+	var panics atomic.Int32
+	results := make(chan *Counter, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if recover() != nil {
+					panics.Add(1)
+				}
+			}()
+			results <- GetFlakyCounter.From(c)
+		}()
+	}
+	close(release)
+	wg.Wait()
+	close(results)
+	if panics.Load() != 1 {
+		t.Errorf("expected only the first construction to panic, got %d panics", panics.Load())
+	}
+	first := <-results
+	for counter := range results {
+		if counter != first {
+			t.Error("expected waiters to share the value built after the panic")
+		}
+	}
+	if n := builds.Load(); n != 2 {
+		t.Errorf("expected constructor to run twice, ran %d times", n)
+	}
+}
+
+func TestMockDuringConstruction(t *testing.T) {
+	building := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	GetSlowPrinter := got.Using(func(c *got.Container) Printer {
+		once.Do(func() { close(building) })
+		<-release
+		return &CapsPrinter{}
+	})
+
+	c := got.New()
+	results := make(chan Printer, 10)
+	for range 10 {
+		go func() { results <- GetSlowPrinter.From(c) }()
+	}
+	<-building
+	var mock Printer = &MockPrinter{}
+	got.Mock(c, GetSlowPrinter, mock)
+	close(release)
+
+	for range 10 {
+		if p := <-results; p != mock {
+			t.Fatalf("expected in-flight construction to return mock, got %T", p)
+		}
+	}
+	if GetSlowPrinter.From(c) != mock {
+		t.Error("expected mock to stick")
+	}
+}
+
+func TestMockConcurrency(t *testing.T) {
+	for range 100 {
+		c := got.New()
+		var mock Printer = &MockPrinter{}
+		var mocked atomic.Bool
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if i == 10 {
+					got.Mock(c, GetPrinter, mock)
+					mocked.Store(true)
+					return
+				}
+				GetPrinter.From(c)
+			}()
+		}
+		wg.Wait()
+		if !mocked.Load() || GetPrinter.From(c) != mock {
+			t.Fatal("expected mock to stick after concurrent resolution")
+		}
+	}
+}
+
 func TestConcurrencyMultipleConstructors(t *testing.T) {
 	c := got.New()
 	var wg sync.WaitGroup
@@ -458,3 +591,213 @@ func TestNestedDependenciesConcurrency(t *testing.T) {
 		t.Errorf("expected exactly 1 Service call, got %d", serviceCalls)
 	}
 }
+
+func TestPanicDoesNotCache(t *testing.T) {
+	var officeCalls int
+	panics := true
+	GetFlakyPrinter := got.Using(func(c *got.Container) Printer {
+		if panics {
+			panic("printer unavailable")
+		}
+		return &CapsPrinter{}
+	})
+	GetFlakyOffice := got.Using(func(c *got.Container) *Office {
+		officeCalls++
+		return &Office{Printer: GetFlakyPrinter.From(c)}
+	})
+
+	c := got.New()
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected constructor to panic")
+			}
+		}()
+		GetFlakyOffice.From(c)
+	}()
+
+	// retry after the recovered panic runs the constructors again
+	panics = false
+	office := GetFlakyOffice.From(c)
+	if officeCalls != 2 {
+		t.Errorf("expected office constructor to run again, got %d calls", officeCalls)
+	}
+	if office.Printer == nil {
+		t.Error("expected office to be fully wired after retry")
+	}
+	if office != GetFlakyOffice.From(c) {
+		t.Error("expected office to be cached after successful retry")
+	}
+}
+
+func TestPanicDoesNotCache2(t *testing.T) {
+	var calls int
+	GetFlaky := got.Using2(func(c *got.Container) (*Counter, error) {
+		calls++
+		if calls == 1 {
+			panic("counter unavailable")
+		}
+		return &Counter{count: calls}, nil
+	})
+
+	c := got.New()
+	func() {
+		defer func() { recover() }()
+		GetFlaky.From(c)
+	}()
+
+	counter, err := GetFlaky.From(c)
+	if err != nil || counter.count != 2 {
+		t.Errorf("expected retry to run constructor again, got %v, %v", counter, err)
+	}
+	if counter2, _ := GetFlaky.From(c); counter2 != counter {
+		t.Error("expected value to be cached after successful retry")
+	}
+}
+
+func TestValue(t *testing.T) {
+	GetPort := got.Value(8080)
+	GetOtherPort := got.Value(8080)
+
+	c := got.New()
+	if GetPort.From(c) != 8080 {
+		t.Error("expected constant value")
+	}
+	got.Mock(c, GetPort, 9090)
+	if GetPort.From(c) != 9090 || GetOtherPort.From(c) != 8080 {
+		t.Error("expected mock to override only its constructor")
+	}
+}
+
+func TestFromReport(t *testing.T) {
+	c := got.New()
+	if _, built := got.FromReport(c, GetCounter); !built {
+		t.Error("expected first call to build")
+	}
+	if _, built := got.FromReport(c, GetCounter); built {
+		t.Error("expected cached value not to be built")
+	}
+	got.Reset(c, GetCounter)
+	if _, built := got.FromReport(c, GetCounter); !built {
+		t.Error("expected value to be built after reset")
+	}
+	got.Mock(c, GetCounter, &Counter{})
+	if _, built := got.FromReport(c, GetCounter); built {
+		t.Error("expected mocked value not to be built")
+	}
+	if _, built := got.FromReport(c, got.Transient(func(*got.Container) int { return 1 })); !built {
+		t.Error("expected transient value to be built")
+	}
+
+	// exactly one of many concurrent callers builds
+	c = got.New()
+	release := make(chan struct{})
+	GetSlow := got.Using(func(c *got.Container) *Counter {
+		<-release
+		return &Counter{}
+	})
+	var builds atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, built := got.FromReport(c, GetSlow); built {
+				builds.Add(1)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+	if builds.Load() != 1 {
+		t.Errorf("expected one caller to build, got %d", builds.Load())
+	}
+}
+
+func TestFromCachedReads(t *testing.T) {
+	c := got.New()
+	counter := GetCounter.From(c)
+	GetCounter.From(c)
+
+	got.Mock(c, GetCounter, &Counter{count: 1})
+	if GetCounter.From(c).count != 1 {
+		t.Error("expected mock after cached reads")
+	}
+	got.Reset(c, GetCounter)
+	if v := GetCounter.From(c); v == counter || v.count != 0 {
+		t.Error("expected value to be rebuilt after reset")
+	} else {
+		counter = v
+	}
+	c.Clear()
+	if GetCounter.From(c) == counter {
+		t.Error("expected value to be rebuilt after clear")
+	}
+
+	// reads alternate between containers
+	other := got.New()
+	if GetCounter.From(other) == GetCounter.From(c) || GetCounter.From(c) != GetCounter.From(c) {
+		t.Error("expected each container to keep its own value")
+	}
+
+	// a scope sees changes to its parent
+	scope := c.Scope()
+	GetCounter.From(scope)
+	got.Mock(c, GetCounter, &Counter{count: 1})
+	if GetCounter.From(scope).count != 1 {
+		t.Error("expected scope to see mock of parent after cached reads")
+	}
+	got.Mock(scope, GetCounter, &Counter{count: 2})
+	if GetCounter.From(scope).count != 2 {
+		t.Error("expected mock of scope to shadow parent")
+	}
+	if GetCounter.From(c).count != 1 {
+		t.Error("expected parent to keep its mock")
+	}
+}
+
+func BenchmarkFrom(b *testing.B) {
+	b.Run("Cached", func(b *testing.B) {
+		c := got.New()
+		GetOffice.From(c)
+		b.ReportAllocs()
+		for b.Loop() {
+			GetOffice.From(c)
+		}
+	})
+	b.Run("CachedInParent", func(b *testing.B) {
+		c := got.New()
+		GetOffice.From(c)
+		scope := c.Scope().Scope()
+		b.ReportAllocs()
+		for b.Loop() {
+			GetOffice.From(scope)
+		}
+	})
+	b.Run("CachedAlternating", func(b *testing.B) {
+		c1, c2 := got.New(), got.New()
+		GetOffice.From(c1)
+		GetOffice.From(c2)
+		b.ReportAllocs()
+		for b.Loop() {
+			GetOffice.From(c1)
+			GetOffice.From(c2)
+		}
+	})
+	b.Run("CachedParallel", func(b *testing.B) {
+		c := got.New()
+		GetOffice.From(c)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				GetOffice.From(c)
+			}
+		})
+	})
+}
+
+func BenchmarkFromCold(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		GetOffice.From(got.New())
+	}
+}
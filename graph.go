@@ -0,0 +1,217 @@
+package got
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// entries returns the entries cached in the container and its ancestors.
+// Entries cached in the container take precedence over entries cached in its ancestors.
+func (c *Container) entries() map[any]*entry {
+	entries := make(map[any]*entry)
+	for b := c.base(); b != nil; b = b.parent {
+		b.cache().Range(func(key, e any) bool {
+			if _, ok := entries[key]; !ok {
+				entries[key] = e.(*entry)
+			}
+			return true
+		})
+	}
+	return entries
+}
+
+type graphNode struct {
+	Type   string `json:"type"`
+	Mocked bool   `json:"mocked"`
+}
+
+type graphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GraphJSON returns the graph of values cached in the container as JSON.
+// Nodes are identified by the type name of their value and edges point from a value to its direct dependencies.
+// Nodes and edges are sorted by type name.
+//
+//	{"nodes":[{"type":"*main.Office","mocked":false}],"edges":[{"from":"*main.Office","to":"main.Printer"}]}
+func (c *Container) GraphJSON() ([]byte, error) {
+	entries := c.entries()
+	graph := struct {
+		Nodes []graphNode `json:"nodes"`
+		Edges []graphEdge `json:"edges"`
+	}{Nodes: []graphNode{}, Edges: []graphEdge{}}
+	for _, e := range entries {
+		graph.Nodes = append(graph.Nodes, graphNode{Type: e.name, Mocked: e.mock})
+		for _, key := range e.deps {
+			if dep, ok := entries[key]; ok {
+				graph.Edges = append(graph.Edges, graphEdge{From: e.name, To: dep.name})
+			}
+		}
+	}
+	slices.SortFunc(graph.Nodes, func(a, b graphNode) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmpBool(a.Mocked, b.Mocked))
+	})
+	slices.SortFunc(graph.Edges, func(a, b graphEdge) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To))
+	})
+	graph.Edges = slices.Compact(graph.Edges)
+	return json.Marshal(graph)
+}
+
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// Graph records the constructors built in a container and the constructors they depend on.
+//
+// Use RecordGraph to start recording a Graph.
+type Graph struct {
+	c *Container
+
+	mu    sync.Mutex
+	keys  []any
+	names map[any]string
+	edges map[any][]any
+}
+
+// RecordGraph starts recording the constructors built in the container and its scopes, and their dependencies,
+// until Stop is called on the returned Graph.
+// Values returned from the cache are recorded as dependencies but their own dependencies are not.
+//
+// It is safe to resolve constructors concurrently while recording.
+func (c *Container) RecordGraph() *Graph {
+	g := &Graph{c: c.base(), names: make(map[any]string), edges: make(map[any][]any)}
+	g.c.mu.Lock()
+	defer g.c.mu.Unlock()
+	g.c.graphs = append(g.c.graphs, g)
+	return g
+}
+
+// Stop stops recording the graph. The recorded graph is kept.
+func (g *Graph) Stop() {
+	g.c.mu.Lock()
+	defer g.c.mu.Unlock()
+	g.c.graphs = slices.DeleteFunc(g.c.graphs, func(other *Graph) bool { return other == g })
+}
+
+// record records the construction of key named name and its dependencies in the graphs recording in the container and its ancestors.
+func (c *Container) record(key any, name string, deps []any) {
+	var graphs []*Graph
+	for b := c.base(); b != nil; b = b.parent {
+		b.mu.Lock()
+		graphs = append(graphs, b.graphs...)
+		b.mu.Unlock()
+	}
+	for _, g := range graphs {
+		g.add(key, name, deps)
+	}
+}
+
+func (g *Graph) add(key any, name string, deps []any) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.node(key)
+	g.names[key] = name
+	for _, dep := range deps {
+		g.node(dep)
+		if !slices.Contains(g.edges[key], dep) {
+			g.edges[key] = append(g.edges[key], dep)
+		}
+	}
+}
+
+func (g *Graph) node(key any) {
+	if _, ok := g.names[key]; !ok {
+		g.keys = append(g.keys, key)
+		g.names[key] = ""
+	}
+}
+
+// DOT returns the recorded graph in the Graphviz DOT language.
+// Constructors are labelled with the name they were registered with using UsingNamed or the type name of their value,
+// constructors with the same label are told apart by a numeric suffix.
+// Nodes and edges are sorted by label.
+func (g *Graph) DOT() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	labels := make(map[any]string, len(g.keys))
+	keys := slices.Clone(g.keys)
+	for _, key := range keys {
+		labels[key] = g.label(key)
+	}
+	slices.SortStableFunc(keys, func(a, b any) int {
+		return cmp.Compare(labels[a], labels[b])
+	})
+	seen := make(map[string]int)
+	for _, key := range keys {
+		label := labels[key]
+		if seen[label]++; seen[label] > 1 {
+			labels[key] = fmt.Sprintf("%s#%d", label, seen[label])
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph got {\n")
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "\t%q;\n", labels[key])
+	}
+	for _, key := range keys {
+		deps := slices.Clone(g.edges[key])
+		slices.SortFunc(deps, func(a, b any) int {
+			return cmp.Compare(labels[a], labels[b])
+		})
+		for _, dep := range deps {
+			fmt.Fprintf(&sb, "\t%q -> %q;\n", labels[key], labels[dep])
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// label returns the registered name of key, the name it was built with or the name of its cached value.
+func (g *Graph) label(key any) string {
+	if name, ok := registered.Load(key); ok {
+		return name.(string)
+	}
+	if name := g.names[key]; name != "" {
+		return name
+	}
+	if e, ok := g.c.load(key); ok {
+		return e.name
+	}
+	return fmt.Sprintf("%T", key)
+}
+
+// FromWithDeps returns an instance of a constructor's value from the container like From,
+// along with the values of its direct dependencies keyed by type name.
+// Values of two-value constructors are reported as a slice of both values.
+//
+// The dependencies are the constructors resolved while the value was built, but their values are read
+// from the container when FromWithDeps is called: a dependency reset or mocked since then reports its current value,
+// and a dependency without a cached value, such as a transient one, is omitted.
+// The dependencies map is empty if the value was mocked or is not cached.
+func FromWithDeps[T any](c *Container, ct Constructor[T]) (T, map[string]any) {
+	var r resolved
+	v := from(c, ct, &r)
+	deps := make(map[string]any)
+	if r.e != nil {
+		for _, key := range r.e.deps {
+			if dep, ok := c.load(key); ok {
+				deps[dep.name] = dep.values()
+			}
+		}
+	}
+	return v, deps
+}
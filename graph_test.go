@@ -0,0 +1,105 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestGraphJSON(t *testing.T) {
+	c := got.New()
+	got.Mock(c, GetCounter, &Counter{})
+	GetCounter.From(c)
+	GetOffice.From(c)
+
+	b, err := c.GraphJSON()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := `{"nodes":[` +
+		`{"type":"*got_test.Counter","mocked":true},` +
+		`{"type":"*got_test.Office","mocked":false},` +
+		`{"type":"got_test.Printer","mocked":false}` +
+		`],"edges":[` +
+		`{"from":"*got_test.Office","to":"got_test.Printer"}` +
+		`]}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+}
+
+func TestRecordGraph(t *testing.T) {
+	c := got.New()
+	GetPrinter.From(c)
+
+	g := c.RecordGraph()
+	GetOffice.From(c)
+	GetReplicaDB.From(c.Scope())
+	g.Stop()
+	GetCounter.From(c)
+
+	expected := "digraph got {\n" +
+		"\t\"*got_test.Office\";\n" +
+		"\t\"db.replica\";\n" +
+		"\t\"got_test.Printer\";\n" +
+		"\t\"*got_test.Office\" -> \"got_test.Printer\";\n" +
+		"}\n"
+	if dot := g.DOT(); dot != expected {
+		t.Errorf("expected %q, got %q", expected, dot)
+	}
+}
+
+func TestRecordGraphSameType(t *testing.T) {
+	GetA := got.Using(func(c *got.Container) *Counter { return &Counter{} })
+	GetB := got.Using(func(c *got.Container) *Counter { return &Counter{} })
+	GetBoth := got.Using(func(c *got.Container) [2]*Counter {
+		return [2]*Counter{GetA.From(c), GetB.From(c)}
+	})
+
+	c := got.New()
+	g := c.RecordGraph()
+	defer g.Stop()
+	GetBoth.From(c)
+
+	expected := "digraph got {\n" +
+		"\t\"*got_test.Counter\";\n" +
+		"\t\"*got_test.Counter#2\";\n" +
+		"\t\"[2]*got_test.Counter\";\n" +
+		"\t\"[2]*got_test.Counter\" -> \"*got_test.Counter\";\n" +
+		"\t\"[2]*got_test.Counter\" -> \"*got_test.Counter#2\";\n" +
+		"}\n"
+	if dot := g.DOT(); dot != expected {
+		t.Errorf("expected %q, got %q", expected, dot)
+	}
+}
+
+func TestFromWithDeps(t *testing.T) {
+	c := got.New()
+	office, deps := got.FromWithDeps(c, GetOffice)
+
+	if len(deps) != 1 {
+		t.Fatalf("expected 1 dependency, got %v", deps)
+	}
+	if deps["got_test.Printer"] != office.Printer {
+		t.Errorf("expected printer dependency, got %v", deps)
+	}
+
+	_, deps = got.FromWithDeps(c, GetPrinter)
+	if len(deps) != 0 {
+		t.Errorf("expected no dependencies, got %v", deps)
+	}
+
+	// dependencies report their current values, transient ones are omitted
+	GetTransientCounter := got.Transient(func(*got.Container) *Counter { return &Counter{} })
+	GetPair := got.Using(func(c *got.Container) *Office {
+		GetTransientCounter.From(c)
+		return &Office{Printer: GetPrinter.From(c)}
+	})
+	GetPair.From(c)
+	mocked := &MockPrinter{}
+	got.Mock[Printer](c, GetPrinter, mocked)
+	_, deps = got.FromWithDeps(c, GetPair)
+	if len(deps) != 1 || deps["got_test.Printer"] != Printer(mocked) {
+		t.Errorf("expected only the mocked printer dependency, got %v", deps)
+	}
+}
@@ -0,0 +1,76 @@
+package got
+
+import (
+	"slices"
+	"sync"
+)
+
+// ConstructorGroup is a group of constructors of the same type resolved together.
+//
+// Use Group to create a new ConstructorGroup.
+type ConstructorGroup[T any] struct {
+	mu      sync.Mutex
+	members []member[T]
+}
+
+type member[T any] struct {
+	ct       Constructor[T]
+	priority int
+}
+
+// Group creates a new empty group of constructors of type T.
+//
+//	var Handlers = got.Group[Handler]()
+//	var GetUsersHandler = Handlers.Add(got.Using(newUsersHandler))
+func Group[T any]() *ConstructorGroup[T] {
+	return &ConstructorGroup[T]{}
+}
+
+// Add adds the constructor to the group with priority 0 and returns the constructor.
+func (g *ConstructorGroup[T]) Add(ct Constructor[T]) Constructor[T] {
+	return g.AddWithPriority(ct, 0)
+}
+
+// AddWithPriority adds the constructor to the group with the given priority and returns the constructor.
+// Constructors with a lower priority come first, constructors with the same priority keep the order they were added in.
+//
+//	var GetAuth = Middleware.AddWithPriority(got.Using(newAuth), -10)
+func (g *ConstructorGroup[T]) AddWithPriority(ct Constructor[T], priority int) Constructor[T] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	i := len(g.members)
+	for i > 0 && g.members[i-1].priority > priority {
+		i--
+	}
+	g.members = slices.Insert(g.members, i, member[T]{ct, priority})
+	return ct
+}
+
+// From returns the values of the constructors in the group from the container in order of priority,
+// then in the order they were added.
+// Each value is resolved and cached like From.
+func (g *ConstructorGroup[T]) From(c *Container) []T {
+	g.mu.Lock()
+	members := slices.Clone(g.members)
+	g.mu.Unlock()
+	values := make([]T, len(members))
+	for i, m := range members {
+		values[i] = From(c, m.ct)
+	}
+	return values
+}
+
+// Concat creates a new Constructor that resolves each constructor and concatenates their slices in argument order.
+// The concatenated slice is cached like any other value.
+//
+// Use Concat to assemble slices contributed by independent modules, for example a middleware chain.
+func Concat[T any](cts ...Constructor[[]T]) Constructor[[]T] {
+	cts = slices.Clone(cts)
+	return Using(func(c *Container) []T {
+		var values []T
+		for _, ct := range cts {
+			values = append(values, ct.From(c)...)
+		}
+		return values
+	})
+}
@@ -0,0 +1,75 @@
+package got_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestGroup(t *testing.T) {
+	printers := got.Group[Printer]()
+	GetCaps := printers.Add(got.Using(func(c *got.Container) Printer {
+		return &CapsPrinter{}
+	}))
+	printers.Add(got.Using(func(c *got.Container) Printer {
+		return &MockPrinter{}
+	}))
+
+	c := got.New()
+	values := printers.From(c)
+	if len(values) != 2 {
+		t.Fatalf("expected 2 values, got %d", len(values))
+	}
+	if _, ok := values[0].(*CapsPrinter); !ok {
+		t.Error("expected values in registration order")
+	}
+	if _, ok := values[1].(*MockPrinter); !ok {
+		t.Error("expected values in registration order")
+	}
+	if values[0] != GetCaps.From(c) || printers.From(c)[1] != values[1] {
+		t.Error("expected members to be cached")
+	}
+
+	// members can be mocked individually
+	mocked := &MockPrinter{}
+	got.Mock[Printer](c, GetCaps, mocked)
+	if printers.From(c)[0] != mocked {
+		t.Error("expected mocked member")
+	}
+
+	if values := got.Group[Printer]().From(c); len(values) != 0 {
+		t.Errorf("expected empty group to resolve no values, got %v", values)
+	}
+}
+
+func TestGroupPriority(t *testing.T) {
+	middleware := got.Group[string]()
+	middleware.Add(got.Value("logging"))
+	middleware.AddWithPriority(got.Value("recover"), -20)
+	middleware.AddWithPriority(got.Value("metrics"), 10)
+	middleware.AddWithPriority(got.Value("auth"), -10)
+	middleware.Add(got.Value("gzip"))
+	middleware.AddWithPriority(got.Value("session"), -10)
+
+	want := []string{"recover", "auth", "session", "logging", "gzip", "metrics"}
+	if values := middleware.From(got.New()); !slices.Equal(values, want) {
+		t.Errorf("expected %v, got %v", want, values)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	GetFirst := got.Using(func(c *got.Container) []string { return []string{"a", "b"} })
+	GetSecond := got.Using(func(c *got.Container) []string { return nil })
+	GetThird := got.Using(func(c *got.Container) []string { return []string{"c"} })
+	GetAll := got.Concat(GetFirst, GetSecond, GetThird)
+
+	c := got.New()
+	all := GetAll.From(c)
+	if !slices.Equal(all, []string{"a", "b", "c"}) {
+		t.Errorf("expected slices in argument order, got %v", all)
+	}
+	if &GetAll.From(c)[0] != &all[0] {
+		t.Error("expected concatenated slice to be cached")
+	}
+}
@@ -0,0 +1,33 @@
+package got
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the container.
+func NewContext(ctx context.Context, c *Container) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the container carried by ctx, if any.
+func FromContext(ctx context.Context) (*Container, bool) {
+	c, ok := ctx.Value(contextKey{}).(*Container)
+	return c, ok
+}
+
+// Middleware returns an HTTP middleware that serves each request with a new scope of the root container.
+// The scope is carried by the request context, use FromContext to retrieve it in handlers.
+//
+// The scope is closed after the handler returns, errors returned by Close are discarded.
+func Middleware(root *Container) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := root.Scope()
+			defer scope.Close()
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), scope)))
+		})
+	}
+}
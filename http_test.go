@@ -0,0 +1,53 @@
+package got_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type RequestState struct{ ID int }
+
+func TestMiddleware(t *testing.T) {
+	var built, closed int
+	GetRequestState := got.Using(func(c *got.Container) *RequestState {
+		built++
+		state := &RequestState{ID: built}
+		got.OnClose(c, func() error {
+			closed++
+			return nil
+		})
+		return state
+	})
+
+	root := got.New()
+	printer := GetPrinter.From(root)
+	handler := got.Middleware(root)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := got.FromContext(r.Context())
+		if !ok {
+			t.Fatal("expected container in request context")
+		}
+		if GetPrinter.From(c) != printer {
+			t.Error("expected root values to be shared with the request scope")
+		}
+		state := GetRequestState.From(c)
+		if state != GetRequestState.From(c) {
+			t.Error("expected value to be cached for the request")
+		}
+		fmt.Fprint(w, state.ID)
+	}))
+
+	for i := 1; i <= 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if body := rec.Body.String(); body != fmt.Sprint(i) {
+			t.Errorf("expected request %d to get its own scope, got %s", i, body)
+		}
+		if closed != i {
+			t.Errorf("expected scope cleanups to run after request %d, got %d", i, closed)
+		}
+	}
+}
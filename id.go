@@ -0,0 +1,42 @@
+package got
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ids holds the constructors created with UsingID by id.
+var ids sync.Map
+
+type identified[T any] struct {
+	id string
+	fn atomic.Pointer[func(*Container) T]
+}
+
+func (ct *identified[T]) New(c *Container) T { return (*ct.fn.Load())(c) }
+
+func (ct *identified[T]) From(c *Container) T { return From(c, ct) }
+
+func (ct *identified[T]) Unwrap() func(*Container) T { return *ct.fn.Load() }
+
+// UsingID creates a new Constructor like Using whose values are cached by id rather than by the constructor.
+// Calling UsingID again with the same id returns the same constructor with fn replacing the previous function,
+// so values cached in a container are kept, for example when a plugin defining the constructor is reloaded.
+// The latest function is used the next time a value is built.
+//
+// UsingID panics if id is already used by a constructor of a type other than T.
+func UsingID[T any](id string, fn func(*Container) T) Constructor[T] {
+	ct := &identified[T]{id: id}
+	ct.fn.Store(&fn)
+	actual, loaded := ids.LoadOrStore(id, ct)
+	if !loaded {
+		return ct
+	}
+	existing, ok := actual.(*identified[T])
+	if !ok {
+		panic(fmt.Sprintf("got: constructor id %q already used for %T", id, actual))
+	}
+	existing.fn.Store(&fn)
+	return existing
+}
@@ -0,0 +1,44 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestUsingID(t *testing.T) {
+	GetV1 := got.UsingID("test.counter", func(c *got.Container) *Counter {
+		return &Counter{count: 1}
+	})
+
+	c := got.New()
+	counter := GetV1.From(c)
+
+	// reloading the constructor keeps the cached value
+	GetV2 := got.UsingID("test.counter", func(c *got.Container) *Counter {
+		return &Counter{count: 2}
+	})
+	if GetV2.From(c) != counter {
+		t.Error("expected constructors with the same id to share the cache")
+	}
+
+	// the latest function builds new values
+	if GetV1.From(got.New()).count != 2 {
+		t.Error("expected latest function to build values")
+	}
+
+	got.Mock(c, GetV2, &Counter{count: 3})
+	if GetV1.From(c).count != 3 {
+		t.Error("expected mocks to be shared by id")
+	}
+}
+
+func TestUsingIDTypeCollision(t *testing.T) {
+	got.UsingID("test.collision", func(c *got.Container) *Counter { return &Counter{} })
+	defer func() {
+		if recover() == nil {
+			t.Error("expected id used by another type to panic")
+		}
+	}()
+	got.UsingID("test.collision", func(c *got.Container) *Office { return &Office{} })
+}
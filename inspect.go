@@ -0,0 +1,70 @@
+package got
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Len returns the number of values cached in the container, including mocked values.
+// Values cached in ancestors of the container are not counted.
+// Len ranges over the cache, so it takes time proportional to the number of cached values.
+func (c *Container) Len() int {
+	n := 0
+	c.base().cache().Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Keys returns the constructors whose values are cached in the container, in no particular order.
+// Constructors cached in ancestors of the container are not included.
+//
+// Use Keys for debugging, compare the keys with constructor variables to identify them.
+func (c *Container) Keys() []any {
+	var keys []any
+	c.base().cache().Range(func(key, _ any) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// ForEachResolved calls fn with each value cached in the container and its ancestors, including mocked values,
+// without building any constructor. Values are visited in order of their type name.
+// For constructors that return multiple values fn receives the first value.
+//
+// The cached values are snapshotted before fn is called,
+// so fn may resolve constructors and other goroutines may resolve concurrently.
+//
+// Use ForEachResolved for health checks by asserting the values to an interface.
+func ForEachResolved(c *Container, fn func(v any)) {
+	entries := slices.SortedFunc(maps.Values(c.entries()), func(a, b *entry) int {
+		return strings.Compare(a.name, b.name)
+	})
+	for _, e := range entries {
+		v, ok := e.get()
+		if !ok {
+			continue
+		}
+		if p, ok := v.(pair); ok {
+			v = p.values().([]any)[0]
+		}
+		fn(v)
+	}
+}
+
+// Has reports whether the constructor's value is cached in the container or its ancestors, including a mocked value.
+// Has never runs the constructor.
+func Has[T any](c *Container, ct Constructor[T]) bool {
+	_, ok := c.load(ct)
+	return ok
+}
+
+// Has2 reports whether the constructor's values are cached in the container or its ancestors, including mocked values.
+// Has2 never runs the constructor.
+func Has2[T, U any](c *Container, ct Constructor2[T, U]) bool {
+	_, ok := c.load(ct)
+	return ok
+}
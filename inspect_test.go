@@ -0,0 +1,94 @@
+package got_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestHas(t *testing.T) {
+	c := got.New()
+	if got.Has(c, GetCounter) {
+		t.Error("expected unresolved constructor not to be cached")
+	}
+	if got.Has(c, GetCounter) {
+		t.Error("expected Has not to resolve the constructor")
+	}
+
+	GetCounter.From(c)
+	if !got.Has(c, GetCounter) {
+		t.Error("expected resolved constructor to be cached")
+	}
+	if !got.Has(c.Scope(), GetCounter) {
+		t.Error("expected constructor cached in parent to be visible from scope")
+	}
+
+	got.Reset(c, GetCounter)
+	if got.Has(c, GetCounter) {
+		t.Error("expected reset constructor not to be cached")
+	}
+
+	got.Mock[Printer](c, GetPrinter, &MockPrinter{})
+	if !got.Has(c, GetPrinter) {
+		t.Error("expected mocked constructor to be cached")
+	}
+}
+
+func TestHas2(t *testing.T) {
+	c := got.New()
+	if got.Has2(c, GetBadOffice) {
+		t.Error("expected unresolved constructor not to be cached")
+	}
+	GetBadOffice.From(c)
+	if !got.Has2(c, GetBadOffice) {
+		t.Error("expected resolved constructor to be cached")
+	}
+}
+
+func TestLen(t *testing.T) {
+	c := got.New()
+	if c.Len() != 0 || len(c.Keys()) != 0 {
+		t.Error("expected empty container")
+	}
+
+	GetOffice.From(c)
+	got.Mock(c, GetCounter, &Counter{})
+	if c.Len() != 3 {
+		t.Errorf("expected 3 cached values, got %d", c.Len())
+	}
+	keys := c.Keys()
+	if len(keys) != 3 || !slices.Contains(keys, any(GetOffice)) || !slices.Contains(keys, any(GetPrinter)) || !slices.Contains(keys, any(GetCounter)) {
+		t.Errorf("expected keys of cached constructors, got %v", keys)
+	}
+
+	scope := c.Scope()
+	GetBadOffice.From(scope)
+	if scope.Len() != 1 || scope.Keys()[0] != any(GetBadOffice) {
+		t.Error("expected scope to count its own values only")
+	}
+}
+
+func TestForEachResolved(t *testing.T) {
+	c := got.New()
+	office := GetOffice.From(c)
+	scope := c.Scope()
+	badOffice, _ := GetBadOffice.From(scope)
+	counter := &Counter{}
+	got.Mock(scope, GetCounter, counter)
+
+	var values []any
+	got.ForEachResolved(scope, func(v any) {
+		values = append(values, v)
+		GetCounter.From(c) // resolving during iteration is allowed
+	})
+	expected := []any{badOffice, counter, office, GetPrinter.From(c)}
+	if len(values) != len(expected) {
+		t.Fatalf("expected %d values, got %v", len(expected), values)
+	}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("expected value %d to be %v, got %v", i, expected[i], values[i])
+		}
+	}
+}
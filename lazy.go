@@ -0,0 +1,21 @@
+package got
+
+// Lazy returns a function that resolves the constructor's value from the container like From when called.
+// The value is cached by the container as usual, so every call returns the same instance.
+//
+// Use Lazy in a constructor to hold a reference to a peer that is resolved later,
+// for example when two services reference each other. Calling the function while the constructor
+// is still running resolves the peer immediately and may panic with ErrCircularDependency.
+func Lazy[T any](c *Container, ct Constructor[T]) func() T {
+	return func() T {
+		if c.frame != nil {
+			c.frame.mu.Lock()
+			done := c.frame.done
+			c.frame.mu.Unlock()
+			if !done {
+				return From(c, ct)
+			}
+		}
+		return From(c.base(), ct)
+	}
+}
@@ -0,0 +1,39 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type Ping struct{ pong func() *Pong }
+
+type Pong struct{ ping *Ping }
+
+var GetPing got.Constructor[*Ping]
+
+var GetPong got.Constructor[*Pong]
+
+func init() {
+	GetPing = got.Using(func(c *got.Container) *Ping {
+		return &Ping{pong: got.Lazy(c, GetPong)}
+	})
+	GetPong = got.Using(func(c *got.Container) *Pong {
+		return &Pong{ping: GetPing.From(c)}
+	})
+}
+
+func TestLazy(t *testing.T) {
+	c := got.New()
+	ping := GetPing.From(c)
+	if got.Has(c, GetPong) {
+		t.Error("expected lazy value not to be resolved before first call")
+	}
+	pong := ping.pong()
+	if pong.ping != ping {
+		t.Error("expected peers to reference each other")
+	}
+	if ping.pong() != pong || GetPong.From(c) != pong {
+		t.Error("expected lazy value to be cached by the container")
+	}
+}
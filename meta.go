@@ -0,0 +1,36 @@
+package got
+
+import "maps"
+
+// NewWithConfig creates a new Container that carries the metadata m, configured with the given options.
+// Constructors read the metadata with Meta, for example to access configuration loaded at startup.
+// The map is copied, changing it afterwards does not affect the container.
+//
+// Metadata is kept apart from the cache: it cannot be mocked, reset or cleared.
+// Scopes and clones of the container share its metadata.
+//
+//	c := got.NewWithConfig(map[string]any{"dsn": os.Getenv("DATABASE_URL")})
+func NewWithConfig[K comparable](m map[K]any, opts ...Option) *Container {
+	c := New(opts...)
+	c.meta = maps.Clone(m)
+	return c
+}
+
+// Meta returns the metadata stored for key in the container or its ancestors,
+// and whether it was found.
+// Metadata is only found with the key type the container was created with.
+//
+//	var GetDB = got.Using(func(c *got.Container) *sql.DB {
+//		dsn, _ := got.Meta(c, "dsn")
+//		db, _ := sql.Open("postgres", dsn.(string))
+//		return db
+//	})
+func Meta[K comparable](c *Container, key K) (any, bool) {
+	for b := c.base(); b != nil; b = b.parent {
+		if m, ok := b.meta.(map[K]any); ok {
+			v, ok := m[key]
+			return v, ok
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,42 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestMeta(t *testing.T) {
+	GetGreeting := got.Using(func(c *got.Container) string {
+		name, _ := got.Meta(c, "name")
+		return "hello " + name.(string)
+	})
+
+	c := got.NewWithConfig(map[string]any{"name": "got"})
+	if v := GetGreeting.From(c); v != "hello got" {
+		t.Errorf("expected metadata in constructor, got %q", v)
+	}
+	if v, ok := got.Meta(c.Scope(), "name"); !ok || v != "got" {
+		t.Error("expected scope to share metadata")
+	}
+	if v, ok := got.Meta(c.Clone(), "name"); !ok || v != "got" {
+		t.Error("expected clone to share metadata")
+	}
+
+	c.Clear()
+	if _, ok := got.Meta(c, "name"); !ok {
+		t.Error("expected metadata to survive clear")
+	}
+	if _, ok := got.Meta(c, "missing"); ok {
+		t.Error("expected missing key not to be found")
+	}
+
+	// other key types are not found
+	type key int
+	if _, ok := got.Meta(c, key(0)); ok {
+		t.Error("expected key of another type not to be found")
+	}
+	if _, ok := got.Meta(got.New(), "name"); ok {
+		t.Error("expected container without metadata")
+	}
+}
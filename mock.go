@@ -0,0 +1,271 @@
+package got
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// MockSnapshot holds the mocks installed in a container at the time of a snapshot.
+type MockSnapshot struct {
+	mocks map[any]*entry
+}
+
+// SnapshotMocks returns a snapshot of the mocks installed in the container.
+// Mocks installed in ancestors of the container are not included.
+func SnapshotMocks(c *Container) MockSnapshot {
+	snap := MockSnapshot{mocks: make(map[any]*entry)}
+	c.base().cache().Range(func(key, e any) bool {
+		if e := e.(*entry); e.mock {
+			snap.mocks[key] = e
+		}
+		return true
+	})
+	return snap
+}
+
+// RestoreMocks replaces the mocks installed in the container with the mocks in the snapshot.
+// Mocks installed since the snapshot are removed and values resolved by constructors are left intact.
+func RestoreMocks(c *Container, snap MockSnapshot) {
+	b := c.base()
+	b.cache().Range(func(key, e any) bool {
+		if e.(*entry).mock && snap.mocks[key] == nil {
+			compareAndDelete(b.cache(), key, e)
+		}
+		return true
+	})
+	for key, e := range snap.mocks {
+		b.cache().Store(key, e)
+	}
+}
+
+// Unmock removes the mocks of the constructors from the container,
+// so the next call to From runs the constructor's New method.
+// Values built by the constructors are left intact, as are mocks installed in ancestors of the container.
+func (c *Container) Unmock(cts ...any) {
+	b := c.base()
+	for _, ct := range cts {
+		if e, ok := b.cache().Load(ct); ok && e.(*entry).mock {
+			compareAndDelete(b.cache(), ct, e)
+		}
+	}
+}
+
+// UnmockAll removes every mock from the container while keeping values built by constructors.
+// Mocks installed in ancestors of the container are left intact.
+func (c *Container) UnmockAll() {
+	RestoreMocks(c, MockSnapshot{})
+}
+
+// ErrNotMocked is the error From panics with when resolving a constructor that is not mocked in a strict container.
+var ErrNotMocked = errors.New("not mocked")
+
+// TestContainer creates a strict container for the test, see WithStrict.
+// Every constructor resolved in the container or its scopes must be mocked, otherwise From panics naming the constructor.
+// The container is closed when the test ends, errors returned by Close fail the test.
+func TestContainer(t testing.TB) *Container {
+	t.Helper()
+	c := New(WithStrict())
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Errorf("got: close test container: %v", err)
+		}
+	})
+	return c
+}
+
+// ErrMocked is the error FromStrict returns when a value or one of its dependencies is mocked.
+var ErrMocked = errors.New("mocked")
+
+// FromStrict returns an instance of a constructor's value from the container like From,
+// and an error wrapping ErrMocked if the value or any of its transitive dependencies was mocked.
+//
+// Use FromStrict in smoke tests of a production container to guard against shipping test doubles.
+func FromStrict[T any](c *Container, ct Constructor[T]) (T, error) {
+	v := From(c, ct)
+	if name, ok := findMock(c, ct, make(map[any]bool)); ok {
+		return v, fmt.Errorf("got: %s: %s is %w", typeName[T](), name, ErrMocked)
+	}
+	return v, nil
+}
+
+// findMock returns the type name of the first mocked value found in the dependency graph of key.
+func findMock(c *Container, key any, visited map[any]bool) (string, bool) {
+	if visited[key] {
+		return "", false
+	}
+	visited[key] = true
+	e, ok := c.load(key)
+	if !ok {
+		return "", false
+	}
+	if e.mock {
+		return e.name, true
+	}
+	for _, dep := range e.deps {
+		if name, ok := findMock(c, dep, visited); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// MockSpec installs a mock in a container.
+//
+// Use MockWith to create a MockSpec.
+type MockSpec func(*Container)
+
+// MockWith returns a MockSpec that mocks the constructor with v.
+func MockWith[T any](ct Constructor[T], v T) MockSpec {
+	return func(c *Container) {
+		Mock(c, ct, v)
+	}
+}
+
+// Mocks installs each mock in the container in order.
+//
+//	got.Mocks(c,
+//		got.MockWith[Printer](GetPrinter, &MockPrinter{}),
+//		got.MockWith(GetCounter, &Counter{}),
+//	)
+func Mocks(c *Container, specs ...MockSpec) {
+	for _, spec := range specs {
+		spec(c)
+	}
+}
+
+// MockUsage counts the uses of a mock installed with MockTracked.
+type MockUsage struct {
+	n atomic.Int64
+}
+
+// Count returns the number of times the mock was returned by From.
+func (u *MockUsage) Count() int {
+	return int(u.n.Load())
+}
+
+// MockTracked mocks the constructor like Mock and returns a MockUsage that counts the calls to From that return the mock,
+// in the container and its scopes.
+// Use MockTracked to check that the code under test actually uses the mock.
+//
+//	usage := got.MockTracked[Printer](c, GetPrinter, &MockPrinter{})
+//	...
+//	if usage.Count() == 0 {
+//		t.Error("printer was never used")
+//	}
+func MockTracked[T any](c *Container, ct Constructor[T], v T) *MockUsage {
+	u := &MockUsage{}
+	c.base().cache().Store(ct, &entry{value: v, name: typeName[T](), mock: true, usage: u})
+	return u
+}
+
+// MockInterface mocks each constructor of the interface I with the same value.
+//
+//	got.MockInterface[Logger](c, &FakeLogger{}, GetAppLogger, GetAuditLogger)
+func MockInterface[I any](c *Container, mock I, cts ...Constructor[I]) {
+	for _, ct := range cts {
+		Mock(c, ct, mock)
+	}
+}
+
+// WithOverrides returns a scope of the container with each mock installed in it.
+// The mocks are only visible through the returned container, so parallel tests that share a container
+// can each override the same constructor without interfering.
+//
+// The returned container is a scope: values cached in c are still returned as they are,
+// including values built from a constructor that is overridden in the scope.
+// Values resolved through the scope are built and cached in the scope and never reach c.
+// To see an override in a value that depends on it, do not resolve the value in c before deriving the scope.
+//
+//	func TestHandler(t *testing.T) {
+//		t.Parallel()
+//		c := got.WithOverrides(app, got.MockWith(GetClock, fixedClock))
+//		...
+//	}
+func WithOverrides(c *Container, overrides ...MockSpec) *Container {
+	s := c.Scope()
+	Mocks(s, overrides...)
+	return s
+}
+
+// MockResult2 modifies the container cache to return a mocked result for the constructor
+// consistent with the Go convention that a value is not returned with a non-nil error.
+// If err is not nil the zero value of T is mocked with err,
+// and a warning is reported to the hooks registered with OnWarn if v is not the zero value.
+//
+// Use Mock2 to mock a value with an error.
+func MockResult2[T any](c *Container, ct Constructor2[T, error], v T, err error) {
+	if err != nil {
+		if !reflect.ValueOf(&v).Elem().IsZero() {
+			c.warn(fmt.Sprintf("got: MockResult2 %s: non-zero value mocked with error %q, using zero value", typeName[T](), err))
+		}
+		var zero T
+		v = zero
+	}
+	Mock2(c, ct, v, err)
+}
+
+// OnWarn registers fn to be called with warnings about questionable use of the container or its scopes,
+// for example a value mocked with an error by MockResult2.
+//
+// Use OnWarn in tests to fail on warnings with t.Error.
+func (c *Container) OnWarn(fn func(msg string)) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.warners = append(b.warners, fn)
+}
+
+// warn calls the warning hooks registered in the container and its ancestors with msg,
+// and reports whether there were any.
+func (c *Container) warn(msg string) bool {
+	var warners []func(string)
+	for b := c.base(); b != nil; b = b.parent {
+		b.mu.Lock()
+		warners = append(warners, b.warners...)
+		b.mu.Unlock()
+	}
+	for _, fn := range warners {
+		fn(msg)
+	}
+	return len(warners) > 0
+}
+
+// Mock modifies the container cache to return a mocked instance for the constructor.
+// A mock installed while the constructor is being built by another goroutine wins,
+// the built value is discarded and that goroutine also returns the mocked instance.
+func Mock[T any](c *Container, ct Constructor[T], v T) {
+	c.base().cache().Store(ct, &entry{value: v, name: typeName[T](), mock: true})
+}
+
+// Mock2 modifies the container cache to return a mocked instance for the constructor.
+// Like Mock, a mock installed while the constructor is being built wins.
+func Mock2[T, U any](c *Container, ct Constructor2[T, U], v1 T, v2 U) {
+	c.base().cache().Store(ct, &entry{value: from2[T, U]{v1, v2}, name: typeName2[T, U](), mock: true})
+}
+
+// MockError2 modifies the container cache to return the real value with a mocked error for the constructor.
+// The real value is resolved from the container, so the constructor runs if it is not already cached.
+// It runs at most once, when MockError2 is called, and From2 returns the mocked result without running it again.
+// If the constructor is already mocked, the value of that mock is kept.
+//
+// Use MockError2 to simulate a failure where the value exists but an operation failed.
+func MockError2[T any, E error](c *Container, ct Constructor2[T, E], err E) {
+	v, _ := From2(c, ct)
+	Mock2(c, ct, v, err)
+}
+
+// MockValue2 modifies the container cache to return a mocked value with the real second value for the constructor.
+// The real second value is resolved from the container, so the constructor runs if it is not already cached.
+// Like MockError2, it runs at most once, when MockValue2 is called.
+func MockValue2[T, U any](c *Container, ct Constructor2[T, U], v T) {
+	_, u := From2(c, ct)
+	Mock2(c, ct, v, u)
+}
+
+// Mock3 modifies the container cache to return a mocked instance for the constructor.
+func Mock3[T, U, V any](c *Container, ct Constructor3[T, U, V], v1 T, v2 U, v3 V) {
+	c.base().cache().Store(ct, &entry{value: from3[T, U, V]{v1, v2, v3}, name: typeName3[T, U, V](), mock: true})
+}
@@ -0,0 +1,284 @@
+package got_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestSnapshotMocks(t *testing.T) {
+	c := got.New()
+	counter := GetCounter.From(c)
+
+	outerPrinter := &MockPrinter{}
+	got.Mock[Printer](c, GetPrinter, outerPrinter)
+
+	t.Run("inner", func(t *testing.T) {
+		innerSnap := got.SnapshotMocks(c)
+		got.Mock[Printer](c, GetPrinter, &MockPrinter{})
+		got.Mock(c, GetOffice, &Office{})
+		got.RestoreMocks(c, innerSnap)
+	})
+
+	if GetPrinter.From(c) != outerPrinter {
+		t.Error("expected outer printer mock to be restored")
+	}
+	if office := GetOffice.From(c); office.Printer != outerPrinter {
+		t.Error("expected inner office mock to be removed")
+	}
+	if GetCounter.From(c) != counter {
+		t.Error("expected resolved values to be left intact")
+	}
+
+	got.RestoreMocks(c, got.MockSnapshot{})
+	if _, ok := GetPrinter.From(c).(*CapsPrinter); !ok {
+		t.Error("expected all mocks to be removed when restoring an empty snapshot")
+	}
+}
+
+func TestFromStrict(t *testing.T) {
+	c := got.New()
+	if _, err := got.FromStrict(c, GetOffice); err != nil {
+		t.Errorf("expected no error without mocks, got %v", err)
+	}
+
+	mc := got.New()
+	got.Mock[Printer](mc, GetPrinter, &MockPrinter{})
+	office, err := got.FromStrict(mc, GetOffice)
+	if !errors.Is(err, got.ErrMocked) {
+		t.Errorf("expected mocked dependency error, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "got_test.Printer") {
+		t.Errorf("expected error to name the mocked dependency, got %v", err)
+	}
+	if office == nil {
+		t.Error("expected value to be returned with the error")
+	}
+}
+
+func TestMockResult2(t *testing.T) {
+	c := got.New()
+	var warnings []string
+	c.OnWarn(func(msg string) { warnings = append(warnings, msg) })
+
+	errDown := errors.New("office closed")
+	got.MockResult2(c, GetBadOffice, &Office{}, errDown)
+	if office, err := GetBadOffice.From(c); office != nil || err != errDown {
+		t.Errorf("expected zero value with error, got %v, %v", office, err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected a warning for non-zero value with error, got %v", warnings)
+	}
+	want := `got: MockResult2 *got_test.Office: non-zero value mocked with error "office closed", using zero value`
+	if warnings[0] != want {
+		t.Errorf("expected %q, got %q", want, warnings[0])
+	}
+
+	office := &Office{}
+	got.MockResult2(c.Scope(), GetBadOffice, office, nil)
+	got.MockResult2(c, GetBadOffice, nil, errDown)
+	if len(warnings) != 1 {
+		t.Errorf("expected no warnings for consistent results, got %v", warnings)
+	}
+}
+
+func TestMocks(t *testing.T) {
+	c := got.New()
+	counter := &Counter{count: 1}
+	got.Mocks(c,
+		got.MockWith[Printer](GetPrinter, &MockPrinter{}),
+		got.MockWith(GetCounter, counter),
+		func(c *got.Container) { got.Mock2(c, GetBadOffice, &Office{}, nil) },
+	)
+
+	if _, ok := GetOffice.From(c).Printer.(*MockPrinter); !ok {
+		t.Error("expected mocked printer")
+	}
+	if GetCounter.From(c) != counter {
+		t.Error("expected mocked counter")
+	}
+	if _, err := GetBadOffice.From(c); err != nil {
+		t.Error("expected custom spec to be installed")
+	}
+}
+
+func TestMockTracked(t *testing.T) {
+	c := got.New()
+	usage := got.MockTracked[Printer](c, GetPrinter, &MockPrinter{})
+	if usage.Count() != 0 {
+		t.Error("expected unused mock")
+	}
+
+	GetOffice.From(c)
+	GetPrinter.From(c)
+	GetPrinter.From(c.Scope())
+	if usage.Count() != 3 {
+		t.Errorf("expected mock to be used 3 times, got %d", usage.Count())
+	}
+
+	got.Reset(c, GetPrinter)
+	GetPrinter.From(c)
+	if usage.Count() != 3 {
+		t.Error("expected real value not to be counted")
+	}
+}
+
+func TestMockInterface(t *testing.T) {
+	GetPlainPrinter := got.Using(func(c *got.Container) Printer { return &CapsPrinter{} })
+
+	c := got.New()
+	mock := &MockPrinter{}
+	got.MockInterface[Printer](c, mock, GetPrinter, GetPlainPrinter)
+	if GetPrinter.From(c) != mock || GetPlainPrinter.From(c) != mock {
+		t.Error("expected every constructor to return the mock")
+	}
+}
+
+func TestWithOverrides(t *testing.T) {
+	c := got.New()
+	GetCounter.From(c)
+
+	for i := range 4 {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+			s := got.WithOverrides(c, got.MockWith(GetCounter, &Counter{count: i}))
+			if GetCounter.From(s).count != i {
+				t.Errorf("expected counter %d", i)
+			}
+		})
+	}
+	t.Run("parent", func(t *testing.T) {
+		t.Parallel()
+		if GetCounter.From(c).count != 0 {
+			t.Error("expected container not to see overrides")
+		}
+	})
+}
+
+func TestTestContainer(t *testing.T) {
+	c := got.TestContainer(t)
+	got.Mock(c, GetOffice, &Office{Printer: &MockPrinter{}})
+	if _, ok := GetOffice.From(c).Printer.(*MockPrinter); !ok {
+		t.Error("expected mocked office")
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, got.ErrNotMocked) || !strings.Contains(err.Error(), "*got_test.Counter") {
+			t.Errorf("expected panic naming the constructor that is not mocked, got %v", err)
+		}
+	}()
+	GetCounter.From(c.Scope())
+}
+
+func TestUnmock(t *testing.T) {
+	c := got.New()
+	counter := GetCounter.From(c)
+	got.Mock[Printer](c, GetPrinter, &MockPrinter{})
+	got.Mock(c, GetOffice, &Office{})
+	got.Mock2(c, GetBadOffice, &Office{}, nil)
+
+	c.Unmock(GetPrinter, GetBadOffice, GetCounter)
+	if _, ok := GetPrinter.From(c).(*CapsPrinter); !ok {
+		t.Error("expected real printer after unmock")
+	}
+	if _, err := GetBadOffice.From(c); err == nil {
+		t.Error("expected real office after unmock")
+	}
+	if GetOffice.From(c).Printer != nil {
+		t.Error("expected other mocks to be kept")
+	}
+	if GetCounter.From(c) != counter {
+		t.Error("expected built values to be kept")
+	}
+
+	c.UnmockAll()
+	if GetOffice.From(c).Printer == nil {
+		t.Error("expected real office after unmock all")
+	}
+	if GetCounter.From(c) != counter {
+		t.Error("expected built values to be kept")
+	}
+}
+
+func TestMockError2Runs(t *testing.T) {
+	var runs int
+	GetRunCounter := got.Using2(func(c *got.Container) (*Counter, error) {
+		runs++
+		return &Counter{}, nil
+	})
+
+	c := got.New()
+	errFailed := errors.New("failed")
+	got.MockError2(c, GetRunCounter, errFailed)
+	counter, err := GetRunCounter.From(c)
+	GetRunCounter.From(c)
+	if runs != 1 || counter == nil || err != errFailed {
+		t.Errorf("expected constructor to run once for the real value, ran %d times", runs)
+	}
+
+	// an existing mock keeps its value
+	mocked := &Counter{count: 1}
+	got.Mock2(c, GetRunCounter, mocked, nil)
+	got.MockError2(c, GetRunCounter, errFailed)
+	if counter, _ := GetRunCounter.From(c); counter != mocked || runs != 1 {
+		t.Error("expected mocked value to be kept")
+	}
+}
+
+func TestMockError2(t *testing.T) {
+	type Database struct{ Host string }
+	GetDB := got.Using2(func(c *got.Container) (*Database, error) {
+		return &Database{Host: "prod.db"}, nil
+	})
+
+	c := got.New()
+	mockErr := fmt.Errorf("connection reset")
+	got.MockError2(c, GetDB, mockErr)
+
+	db, err := GetDB.From(c)
+	if db == nil || db.Host != "prod.db" {
+		t.Errorf("expected real database, got %v", db)
+	}
+	if err != mockErr {
+		t.Errorf("expected mocked error, got %v", err)
+	}
+}
+
+func TestMockValue2(t *testing.T) {
+	c := got.New()
+	realOffice, realErr := GetBadOffice.From(c)
+	if realOffice != nil {
+		t.Fatal("expected no real office")
+	}
+
+	mockOffice := &Office{}
+	got.MockValue2(c, GetBadOffice, mockOffice)
+
+	office, err := GetBadOffice.From(c)
+	if office != mockOffice {
+		t.Error("expected mocked office")
+	}
+	if err != realErr {
+		t.Errorf("expected real error to be kept, got %v", err)
+	}
+}
+
+func TestMock3(t *testing.T) {
+	GetClient := got.Using3(func(c *got.Container) (*Counter, string, error) {
+		return &Counter{}, "real", nil
+	})
+
+	c := got.New()
+	mockClient := &Counter{count: 9}
+	mockErr := fmt.Errorf("dial failed")
+	got.Mock3(c, GetClient, mockClient, "mock", mockErr)
+
+	client, name, err := GetClient.From(c)
+	if client != mockClient || name != "mock" || err != mockErr {
+		t.Errorf("expected mocked values, got %v, %q, %v", client, name, err)
+	}
+}
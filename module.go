@@ -0,0 +1,26 @@
+package got
+
+import "slices"
+
+// Module groups providers into a single provider that warms them in order, see Warm.
+// A provider typically calls a constructor's From method, and modules can be nested in other modules.
+//
+//	var Storage = got.Module(
+//		func(c *got.Container) { GetDB.From(c) },
+//		func(c *got.Container) { GetCache.From(c) },
+//	)
+func Module(providers ...func(*Container)) func(*Container) {
+	providers = slices.Clone(providers)
+	return func(c *Container) {
+		c.Warm(providers...)
+	}
+}
+
+// Build creates a new Container and warms the providers in it in order, see Warm.
+//
+//	c := got.Build(Storage, HTTP)
+func Build(providers ...func(*Container)) *Container {
+	c := New()
+	c.Warm(providers...)
+	return c
+}
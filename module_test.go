@@ -0,0 +1,32 @@
+package got_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestModule(t *testing.T) {
+	var order []string
+	provide := func(name string) func(*got.Container) {
+		ct := got.Using(func(c *got.Container) string {
+			order = append(order, name)
+			return name
+		})
+		return func(c *got.Container) { ct.From(c) }
+	}
+
+	storage := got.Module(provide("db"), provide("cache"))
+	c := got.Build(storage, got.Module(provide("http")))
+	if want := []string{"db", "cache", "http"}; !slices.Equal(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+
+	// providers run again in other containers, values are cached per container
+	storage(c)
+	storage(got.New())
+	if len(order) != 5 {
+		t.Errorf("expected values to be cached per container, got %v", order)
+	}
+}
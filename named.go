@@ -0,0 +1,100 @@
+package got
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// names holds the constructors registered with UsingNamed or Key,
+// and registered holds their names by constructor.
+var names, registered sync.Map
+
+// UsingNamed creates a new Constructor like Using and registers it under name,
+// so it can be resolved with FromNamed where the constructor's variable is not in scope.
+// UsingNamed panics if a constructor is already registered under name.
+//
+//	var GetReplica = got.UsingNamed("db.replica", newReplica)
+func UsingNamed[T any](name string, fn func(*Container) T) Constructor[T] {
+	ct := Using(fn)
+	actual, loaded := names.LoadOrStore(name, ct)
+	if !loaded {
+		registered.Store(ct, name)
+		return ct
+	}
+	// a constructor created with Key takes the function if it has none yet.
+	if k, ok := actual.(*keyed[T]); ok && k.fn.CompareAndSwap(nil, &fn) {
+		return k
+	}
+	panic(fmt.Sprintf("got: constructor named %q already registered", name))
+}
+
+type keyed[T any] struct {
+	name string
+	fn   atomic.Pointer[func(*Container) T]
+}
+
+func (ct *keyed[T]) New(c *Container) T {
+	fn := ct.fn.Load()
+	if fn == nil {
+		panic(fmt.Sprintf("got: no constructor registered for key %q", ct.name))
+	}
+	return (*fn)(c)
+}
+
+func (ct *keyed[T]) From(c *Container) T { return From(c, ct) }
+
+func (ct *keyed[T]) Unwrap() func(*Container) T {
+	if fn := ct.fn.Load(); fn != nil {
+		return *fn
+	}
+	return nil
+}
+
+// Key returns the constructor of T registered under name, see UsingNamed.
+// If no constructor is registered under name yet, Key registers one without a function,
+// which Register or UsingNamed set later.
+// Every call with the same name returns the same constructor, so values are cached by name
+// rather than by the identity of a variable, and the constructor can be resolved and mocked like any other.
+//
+// The constructor panics when built if no function is set for name.
+// Key panics if the constructor registered under name is not of type T.
+//
+//	got.Register("db", newDB)
+//	db := got.Key[*sql.DB]("db").From(c)
+func Key[T any](name string) Constructor[T] {
+	k := &keyed[T]{name: name}
+	actual, loaded := names.LoadOrStore(name, k)
+	if !loaded {
+		registered.Store(k, name)
+		return k
+	}
+	ct, ok := actual.(Constructor[T])
+	if !ok {
+		panic(fmt.Sprintf("got: constructor named %q is not a constructor of %s", name, typeName[T]()))
+	}
+	return ct
+}
+
+// Register registers fn as the function of the constructor of T named name, like UsingNamed,
+// for callers that refer to the constructor with Key instead of a variable.
+// Register panics if a function is already registered under name.
+func Register[T any](name string, fn func(*Container) T) {
+	UsingNamed(name, fn)
+}
+
+// FromNamed returns an instance of the value of the constructor registered under name from the container like From.
+// FromNamed returns false if no constructor is registered under name or its value is not of type T.
+func FromNamed[T any](c *Container, name string) (T, bool) {
+	v, ok := names.Load(name)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	ct, ok := v.(Constructor[T])
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return From(c, ct), true
+}
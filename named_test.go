@@ -0,0 +1,100 @@
+package got_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type Database struct{ Host string }
+
+var (
+	GetPrimaryDB = got.UsingNamed("db.primary", func(c *got.Container) *Database {
+		return &Database{Host: "primary"}
+	})
+	GetReplicaDB = got.UsingNamed("db.replica", func(c *got.Container) *Database {
+		return &Database{Host: "replica"}
+	})
+)
+
+func TestFromNamed(t *testing.T) {
+	c := got.New()
+	primary, ok := got.FromNamed[*Database](c, "db.primary")
+	if !ok || primary.Host != "primary" {
+		t.Errorf("expected primary database, got %v, %v", primary, ok)
+	}
+	if primary != GetPrimaryDB.From(c) {
+		t.Error("expected named value to share the constructor's cache")
+	}
+	replica, ok := got.FromNamed[*Database](c, "db.replica")
+	if !ok || replica == primary || replica != GetReplicaDB.From(c) {
+		t.Errorf("expected replica database, got %v, %v", replica, ok)
+	}
+
+	if _, ok := got.FromNamed[*Database](c, "db.missing"); ok {
+		t.Error("expected unregistered name not to resolve")
+	}
+	if _, ok := got.FromNamed[*Office](c, "db.primary"); ok {
+		t.Error("expected mismatched type not to resolve")
+	}
+}
+
+func TestUsingNamedDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected duplicate name to panic")
+		}
+	}()
+	got.UsingNamed("db.primary", func(c *got.Container) *Database {
+		return &Database{}
+	})
+}
+
+// keyRuns makes the names used by TestKey unique to each run of the test.
+var keyRuns atomic.Int32
+
+func TestKey(t *testing.T) {
+	name := fmt.Sprintf("test.key.%d", keyRuns.Add(1))
+	c := got.New()
+	if got.Key[*Counter](name) != got.Key[*Counter](name) {
+		t.Error("expected keys with the same name to be the same constructor")
+	}
+
+	// a key can be mocked before it is registered
+	got.Mock(c, got.Key[*Counter](name), &Counter{count: 1})
+	if got.Key[*Counter](name).From(c).count != 1 {
+		t.Error("expected mocked key")
+	}
+
+	got.Register(name, func(c *got.Container) *Counter { return &Counter{count: 2} })
+	if got.Key[*Counter](name).From(got.New()).count != 2 {
+		t.Error("expected registered function to build the value")
+	}
+	if v, ok := got.FromNamed[*Counter](got.New(), name); !ok || v.count != 2 {
+		t.Error("expected key to be resolved by FromNamed")
+	}
+
+	// keys of constructors registered with UsingNamed return the constructor
+	if got.Key[*Database]("db.primary") != GetPrimaryDB {
+		t.Error("expected key to return the named constructor")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected registering a key twice to panic")
+			}
+		}()
+		got.Register(name, func(c *got.Container) *Counter { return &Counter{} })
+	}()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected resolving an unregistered key to panic")
+			}
+		}()
+		got.Key[*Counter](fmt.Sprintf("test.unregistered.%d", keyRuns.Load())).From(got.New())
+	}()
+}
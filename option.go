@@ -0,0 +1,105 @@
+package got
+
+import "time"
+
+// Option configures a Container.
+type Option func(*options)
+
+type options struct {
+	policy       func(typeName string) error
+	interceptors []Interceptor
+	barrier      func(typeName string)
+	strict       bool
+	errorChain   bool
+	closeTimeout time.Duration
+	capacity     int
+}
+
+// WithConstructPolicy sets a policy that is consulted before each constructor is built in the container.
+// The policy receives the type name of the constructor's value,
+// a non-nil error aborts the construction and From panics with the error.
+//
+// Use WithConstructPolicy to forbid building certain services in a container, for example debug services in production.
+func WithConstructPolicy(policy func(typeName string) error) Option {
+	return func(o *options) {
+		o.policy = policy
+	}
+}
+
+// WithStrict forbids building any constructor in the container, every value must be mocked.
+// Resolving a constructor that is not mocked panics with an error wrapping ErrNotMocked that names the constructor.
+//
+// Use WithStrict in tests to make every dependency explicit, see TestContainer.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithErrorChain wraps errors returned by constructors resolved with From2 in the container in a *ResolveError
+// whose Chain lists the constructors that led to the failing one, for example ["*main.App", "*main.Store", "(*sql.DB, error)"].
+// Errors are wrapped once by the constructor that returned them, dependents returning the same error do not wrap it again.
+// Only constructors whose second value is of type error are wrapped.
+func WithErrorChain() Option {
+	return func(o *options) {
+		o.errorChain = true
+	}
+}
+
+// WithConstructBarrier sets a function that is called at the start of each construction in the container,
+// before the constructor runs and after the construction has been claimed by the caller,
+// with the type name of the constructor's value.
+//
+// WithConstructBarrier is for tests only: block in barrier, for example on a channel,
+// to force a specific interleaving of concurrent resolutions instead of relying on goroutine scheduling.
+func WithConstructBarrier(barrier func(typeName string)) Option {
+	return func(o *options) {
+		o.barrier = barrier
+	}
+}
+
+// WithCloseTimeout makes Close wait at most d for constructions running in the background to end,
+// such as constructions abandoned by FromTimeout, after canceling their context, see Container.Context.
+// By default Close cancels them without waiting.
+func WithCloseTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.closeTimeout = d
+	}
+}
+
+// WithCapacity sizes the cache of a container created with NewWith for n values ahead of use,
+// if the cache has a Grow(n int) method, to avoid growing it while a large graph is built.
+// The default sync.Map cache cannot be sized, so WithCapacity has no effect on containers created with New.
+func WithCapacity(n int) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// Resolution describes a constructor being built.
+type Resolution struct {
+	// Container is the container passed to the constructor.
+	Container *Container
+	// Constructor is the constructor being built.
+	Constructor any
+	// Type is the type name of the constructor's value.
+	Type string
+}
+
+// ResolveFunc builds the value of a constructor.
+type ResolveFunc func(r Resolution) any
+
+// Interceptor wraps the ResolveFunc that builds a constructor's value.
+type Interceptor func(next ResolveFunc) ResolveFunc
+
+// WithInterceptor adds an interceptor that wraps every construction in the container,
+// for example to trace, time or log constructions.
+// Interceptors run on cache misses only, interceptors added first run outermost.
+//
+// An interceptor may short-circuit a construction by returning a value of the constructor's type without calling next.
+// Two-value constructors cannot be short-circuited and their value must be returned as produced by next.
+func WithInterceptor(interceptor Interceptor) Option {
+	return func(o *options) {
+		o.interceptors = append(o.interceptors, interceptor)
+	}
+}
@@ -0,0 +1,110 @@
+package got_test
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestWithConstructPolicy(t *testing.T) {
+	errForbidden := errors.New("forbidden in production")
+	c := got.New(got.WithConstructPolicy(func(typeName string) error {
+		if typeName == "*got_test.Office" {
+			return errForbidden
+		}
+		return nil
+	}))
+
+	if _, ok := GetPrinter.From(c).(*CapsPrinter); !ok {
+		t.Error("expected allowed type to be built")
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, errForbidden) {
+			t.Errorf("expected policy error panic, got %v", err)
+		}
+	}()
+	GetOffice.From(c)
+}
+
+func TestWithInterceptor(t *testing.T) {
+	var events []string
+	logger := func(prefix string) got.Interceptor {
+		return func(next got.ResolveFunc) got.ResolveFunc {
+			return func(r got.Resolution) any {
+				events = append(events, prefix+" before "+r.Type)
+				v := next(r)
+				events = append(events, prefix+" after "+r.Type)
+				return v
+			}
+		}
+	}
+	c := got.New(got.WithInterceptor(logger("outer")), got.WithInterceptor(logger("inner")))
+	GetOffice.From(c)
+	GetOffice.From(c)
+
+	expected := []string{
+		"outer before *got_test.Office",
+		"inner before *got_test.Office",
+		"outer before got_test.Printer",
+		"inner before got_test.Printer",
+		"inner after got_test.Printer",
+		"outer after got_test.Printer",
+		"inner after *got_test.Office",
+		"outer after *got_test.Office",
+	}
+	if strings.Join(events, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected events:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(events, "\n"))
+	}
+}
+
+func TestWithInterceptorShortCircuit(t *testing.T) {
+	mockPrinter := &MockPrinter{}
+	c := got.New(got.WithInterceptor(func(next got.ResolveFunc) got.ResolveFunc {
+		return func(r got.Resolution) any {
+			if r.Constructor == GetPrinter {
+				return Printer(mockPrinter)
+			}
+			return next(r)
+		}
+	}))
+
+	if office := GetOffice.From(c); office.Printer != mockPrinter {
+		t.Error("expected interceptor to short-circuit printer construction")
+	}
+}
+
+func TestWithConstructBarrier(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	var barriers atomic.Int32
+	c := got.New(got.WithConstructBarrier(func(typeName string) {
+		if typeName == "*got_test.Counter" && barriers.Add(1) == 1 {
+			close(entered)
+			<-release
+		}
+	}))
+
+	var builds atomic.Int32
+	GetCounter := got.Using(func(c *got.Container) *Counter {
+		builds.Add(1)
+		return &Counter{}
+	})
+	results := make(chan *Counter, 2)
+	go func() { results <- GetCounter.From(c) }()
+	// the first goroutine holds the construction, the second one races it on the same key
+	<-entered
+	go func() { results <- GetCounter.From(c) }()
+	close(release)
+
+	first, second := <-results, <-results
+	if first != second {
+		t.Error("expected both goroutines to get the same value")
+	}
+	if builds.Load() != 1 || barriers.Load() != 1 {
+		t.Errorf("expected exactly one construction, got %d builds and %d barriers", builds.Load(), barriers.Load())
+	}
+}
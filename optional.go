@@ -0,0 +1,49 @@
+package got
+
+// UsingOptional creates a new Constructor2 for a dependency that may be absent,
+// from a function that returns a value and whether it is present.
+//
+//	var GetCache = got.UsingOptional(func(c *got.Container) (*redis.Client, bool) {
+//		addr := os.Getenv("REDIS_ADDR")
+//		return redis.NewClient(&redis.Options{Addr: addr}), addr != ""
+//	})
+func UsingOptional[T any](fn func(*Container) (T, bool)) Constructor2[T, bool] {
+	return Using2(fn)
+}
+
+// FromOptional returns an instance of an optional constructor's value from the container like From2,
+// and whether the value is present.
+// The zero value of T is returned when the value is absent.
+func FromOptional[T any](c *Container, ct Constructor2[T, bool]) (T, bool) {
+	v, ok := From2(c, ct)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return v, true
+}
+
+// FromOrDefault returns an instance of an optional constructor's value from the container like FromOptional,
+// or def when the value is absent.
+func FromOrDefault[T any](c *Container, ct Constructor2[T, bool], def T) T {
+	if v, ok := FromOptional(c, ct); ok {
+		return v
+	}
+	return def
+}
+
+// FromFallback2 returns an instance of the primary constructor's values from the container like From2,
+// resolving the secondary constructor when the primary constructor returns an error.
+// The values returned by the secondary constructor, including its error, are cached for the primary constructor,
+// so later calls to FromFallback2 or From2 with the primary constructor return them without building either again.
+//
+//	store, err := got.FromFallback2(c, GetRedisStore, GetMemoryStore)
+func FromFallback2[T any](c *Container, primary, secondary Constructor2[T, error]) (T, error) {
+	return resolve2(c, primary, func(c *Container) (T, error) {
+		v, err := primary.New(c)
+		if err != nil {
+			return From2(c, secondary)
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,77 @@
+package got_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestFromOptional(t *testing.T) {
+	present := true
+	GetOptionalPrinter := got.UsingOptional(func(c *got.Container) (Printer, bool) {
+		return &CapsPrinter{}, present
+	})
+
+	c := got.New()
+	p, ok := got.FromOptional(c, GetOptionalPrinter)
+	if _, caps := p.(*CapsPrinter); !ok || !caps {
+		t.Errorf("expected present printer, got %v, %v", p, ok)
+	}
+	if got.FromOrDefault[Printer](c, GetOptionalPrinter, &MockPrinter{}) != p {
+		t.Error("expected present value instead of default")
+	}
+
+	present = false
+	other := got.New()
+	if p, ok := got.FromOptional(other, GetOptionalPrinter); ok || p != nil {
+		t.Errorf("expected absent printer, got %v, %v", p, ok)
+	}
+	def := &MockPrinter{}
+	if got.FromOrDefault[Printer](other, GetOptionalPrinter, def) != def {
+		t.Error("expected default for absent value")
+	}
+
+	// absent values can be mocked as present
+	got.Mock2[Printer](other, GetOptionalPrinter, def, true)
+	if p, ok := got.FromOptional(other, GetOptionalPrinter); !ok || p != def {
+		t.Error("expected mocked value to be present")
+	}
+}
+
+func TestFromFallback2(t *testing.T) {
+	errDown := errors.New("redis down")
+	var primaryBuilds int
+	GetPrimary := got.Using2(func(c *got.Container) (string, error) {
+		primaryBuilds++
+		return "", errDown
+	})
+	GetSecondary := got.Using2(func(c *got.Container) (string, error) {
+		return "memory", nil
+	})
+
+	c := got.New()
+	if v, err := got.FromFallback2(c, GetPrimary, GetSecondary); v != "memory" || err != nil {
+		t.Errorf("expected secondary value, got %q, %v", v, err)
+	}
+	if v, err := GetPrimary.From(c); v != "memory" || err != nil || primaryBuilds != 1 {
+		t.Error("expected secondary value to be cached for the primary constructor")
+	}
+
+	// the primary value is used when it succeeds
+	GetWorking := got.Using2(func(c *got.Container) (string, error) { return "redis", nil })
+	if v, _ := got.FromFallback2(c, GetWorking, GetSecondary); v != "redis" {
+		t.Errorf("expected primary value, got %q", v)
+	}
+
+	// the secondary error is returned and cached
+	errFull := errors.New("memory full")
+	GetFull := got.Using2(func(c *got.Container) (string, error) { return "", errFull })
+	c = got.New()
+	if _, err := got.FromFallback2(c, GetPrimary, GetFull); err != errFull {
+		t.Errorf("expected secondary error, got %v", err)
+	}
+	if _, err := GetPrimary.From(c); err != errFull {
+		t.Errorf("expected secondary error to be cached, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package got
+
+// NewIn calls the constructor's New method with the dependency container and caches the value in the container,
+// replacing any value already cached for the constructor.
+// Dependencies resolved by the constructor come from the dependency container.
+//
+// Use NewIn to unit test a single constructor against a container of mocked dependencies.
+func NewIn[T any](c *Container, deps *Container, ct Constructor[T]) T {
+	v := ct.New(deps)
+	c.base().cache().Store(ct, &entry{value: v, name: typeName[T]()})
+	return v
+}
+
+// FactoryOf returns the function that builds values for the constructor,
+// if the constructor exposes it with an Unwrap() func(*Container) T method.
+// Constructors created with Using and Transient expose their function.
+//
+// Use FactoryOf in libraries to wrap a constructor's function generically, for example with Override.
+func FactoryOf[T any](ct Constructor[T]) (func(*Container) T, bool) {
+	if u, ok := ct.(interface{ Unwrap() func(*Container) T }); ok {
+		return u.Unwrap(), true
+	}
+	return nil, false
+}
+
+// factory returns the function that builds values for the constructor in the container.
+// A factory set in the container or its nearest ancestor replaces the constructor's New method, factory returns nil if there is none.
+func factory[T any](c *Container, ct Constructor[T]) func(*Container) T {
+	for b := c.base(); b != nil; b = b.parent {
+		b.mu.Lock()
+		fn, ok := b.factories[ct]
+		b.mu.Unlock()
+		if ok {
+			return fn.(func(*Container) T)
+		}
+	}
+	return nil
+}
+
+// newDecorated builds a new value of the constructor with the function returned by factory, or its New method,
+// and decorates it.
+func newDecorated[T any](c *Container, ct Constructor[T]) T {
+	if fn := factory(c, ct); fn != nil {
+		return decorate(c, ct, fn(c))
+	}
+	return decorate(c, ct, ct.New(c))
+}
+
+// setFactory replaces the function that builds values for the constructor in the container.
+func setFactory[T any](c *Container, ct Constructor[T], fn func(*Container) T) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.factories == nil {
+		b.factories = make(map[any]any)
+	}
+	b.factories[ct] = fn
+}
+
+// Override replaces the function that builds values for the constructor in the container.
+// Unlike Mock, the function runs lazily on the next call to From and can resolve its own dependencies from the container.
+// Setting an override drops the value cached for the constructor in that container.
+// Reset drops the override and restores the constructor's New method.
+func Override[T any](c *Container, ct Constructor[T], fn func(*Container) T) {
+	setFactory(c, ct, fn)
+	c.base().cache().Delete(ct)
+}
+
+// Overridable creates a Constructor that resolves the default constructor
+// unless an override was set for the container using the returned override function.
+// Setting an override drops the value cached for the constructor in that container.
+//
+// Use Overridable in libraries to ship a default implementation that consumers can replace per container.
+func Overridable[T any](defaultCtor Constructor[T]) (get Constructor[T], override func(*Container, Constructor[T])) {
+	get = Using(defaultCtor.From)
+	override = func(c *Container, ct Constructor[T]) {
+		setFactory(c, get, ct.From)
+		c.base().cache().Delete(get)
+	}
+	return get, override
+}
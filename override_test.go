@@ -0,0 +1,98 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestOverridable(t *testing.T) {
+	GetLibPrinter, overridePrinter := got.Overridable(GetPrinter)
+	GetMockedPrinter := got.Using(func(c *got.Container) Printer {
+		return &MockPrinter{}
+	})
+
+	c := got.New()
+	if _, ok := GetLibPrinter.From(c).(*CapsPrinter); !ok {
+		t.Error("expected default printer when not overridden")
+	}
+
+	overridePrinter(c, GetMockedPrinter)
+	if GetLibPrinter.From(c) != GetMockedPrinter.From(c) {
+		t.Error("expected override printer after override")
+	}
+
+	// overrides are per container
+	other := got.New()
+	if _, ok := GetLibPrinter.From(other).(*CapsPrinter); !ok {
+		t.Error("expected default printer in other container")
+	}
+}
+
+func TestOverride(t *testing.T) {
+	c := got.New()
+	office := GetOffice.From(c)
+
+	got.Override(c, GetOffice, func(c *got.Container) *Office {
+		return &Office{Printer: &MockPrinter{}}
+	})
+	overridden := GetOffice.From(c)
+	if overridden == office {
+		t.Error("expected override to drop cached value")
+	}
+	if _, ok := overridden.Printer.(*MockPrinter); !ok {
+		t.Error("expected override function to build the value")
+	}
+	if GetOffice.From(c) != overridden {
+		t.Error("expected overridden value to be cached")
+	}
+
+	// overrides resolve dependencies lazily
+	got.Override(c, GetOffice, func(c *got.Container) *Office {
+		return &Office{Printer: GetPrinter.From(c)}
+	})
+	got.Mock[Printer](c, GetPrinter, &MockPrinter{})
+	if _, ok := GetOffice.From(c).Printer.(*MockPrinter); !ok {
+		t.Error("expected override to resolve mocked dependency")
+	}
+
+	// reset restores the original constructor
+	got.Reset(c, GetOffice)
+	got.Reset(c, GetPrinter)
+	if _, ok := GetOffice.From(c).Printer.(*CapsPrinter); !ok {
+		t.Error("expected original constructor after reset")
+	}
+}
+
+func TestNewIn(t *testing.T) {
+	deps := got.New()
+	mockPrinter := &MockPrinter{}
+	got.Mock[Printer](deps, GetPrinter, mockPrinter)
+
+	c := got.New()
+	office := got.NewIn(c, deps, GetOffice)
+	if office.Printer != mockPrinter {
+		t.Error("expected office to be built with the mocked printer")
+	}
+	if GetOffice.From(c) != office {
+		t.Error("expected office to be cached in the container")
+	}
+	if _, ok := GetPrinter.From(c).(*CapsPrinter); !ok {
+		t.Error("expected container dependencies to be untouched")
+	}
+}
+
+func TestFactoryOf(t *testing.T) {
+	fn, ok := got.FactoryOf(GetOffice)
+	if !ok {
+		t.Fatal("expected factory of constructor created with Using")
+	}
+	c := got.New()
+	if office := fn(c); office == GetOffice.From(c) || office.Printer != GetPrinter.From(c) {
+		t.Error("expected factory to build a new value with cached dependencies")
+	}
+
+	if _, ok := got.FactoryOf(got.Bind[Printer](GetCapsPrinter)); ok {
+		t.Error("expected no factory for constructor without Unwrap")
+	}
+}
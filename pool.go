@@ -0,0 +1,56 @@
+package got
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// Pool holds a fixed set of instances handed out in round-robin order.
+// It is safe for concurrent use by multiple goroutines.
+type Pool[T any] struct {
+	items []T
+	next  atomic.Uint64
+}
+
+// Next returns the next instance in the pool.
+func (p *Pool[T]) Next() T {
+	n := p.next.Add(1) - 1
+	return p.items[n%uint64(len(p.items))]
+}
+
+// Len returns the number of instances in the pool.
+func (p *Pool[T]) Len() int {
+	return len(p.items)
+}
+
+// Close closes every instance in the pool that implements io.Closer and returns the joined errors.
+func (p *Pool[T]) Close() error {
+	var errs []error
+	for _, item := range p.items {
+		if closer, ok := any(item).(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UsingPool creates a new Constructor for a pool of size instances.
+// The function is called once for each index of the pool when the pool is built.
+// The pool is closed when the container is closed.
+//
+// Use UsingPool for clients that are shared round-robin, for example a small set of connections.
+func UsingPool[T any](size int, fn func(c *Container, i int) T) Constructor[*Pool[T]] {
+	if size <= 0 {
+		panic("got: pool size must be positive")
+	}
+	return Using(func(c *Container) *Pool[T] {
+		items := make([]T, size)
+		for i := range items {
+			items[i] = fn(c, i)
+		}
+		pool := &Pool[T]{items: items}
+		OnClose(c, pool.Close)
+		return pool
+	})
+}
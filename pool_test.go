@@ -0,0 +1,49 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type Conn struct {
+	ID     int
+	closed bool
+}
+
+func (c *Conn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestUsingPool(t *testing.T) {
+	var calls int
+	GetConns := got.UsingPool(3, func(c *got.Container, i int) *Conn {
+		calls++
+		return &Conn{ID: i}
+	})
+
+	c := got.New()
+	pool := GetConns.From(c)
+	if pool != GetConns.From(c) {
+		t.Error("expected pool to be cached")
+	}
+	if calls != 3 || pool.Len() != 3 {
+		t.Errorf("expected 3 instances, got %d calls and %d instances", calls, pool.Len())
+	}
+
+	for i := range 6 {
+		if conn := pool.Next(); conn.ID != i%3 {
+			t.Errorf("expected instance %d, got %d", i%3, conn.ID)
+		}
+	}
+
+	if err := c.Close(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	for range 3 {
+		if conn := pool.Next(); !conn.closed {
+			t.Errorf("expected instance %d to be closed", conn.ID)
+		}
+	}
+}
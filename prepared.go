@@ -0,0 +1,34 @@
+package got
+
+// PreparedScope is a scope of a container with substitutions installed once
+// that can be reset cheaply between uses.
+type PreparedScope struct {
+	scope *Container
+}
+
+// Prepare creates a PreparedScope of the container.
+// Each substitute function is called once with the scope to install substitutions, typically by calling Mock.
+//
+// Use Prepare in benchmarks to resolve a component many times against substituted dependencies.
+func Prepare(c *Container, substitute ...func(*Container)) *PreparedScope {
+	scope := c.Scope()
+	for _, fn := range substitute {
+		fn(scope)
+	}
+	return &PreparedScope{scope}
+}
+
+// Container returns the scope to resolve constructors from.
+func (p *PreparedScope) Container() *Container {
+	return p.scope
+}
+
+// Reset drops the values resolved in the scope while keeping its substitutions.
+func (p *PreparedScope) Reset() {
+	p.scope.cache().Range(func(key, e any) bool {
+		if !e.(*entry).mock {
+			p.scope.cache().Delete(key)
+		}
+		return true
+	})
+}
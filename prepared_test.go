@@ -0,0 +1,43 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type NoopPrinter struct{}
+
+func (NoopPrinter) Print(s string) string { return s }
+
+func TestPreparedScope(t *testing.T) {
+	c := got.New()
+	ps := got.Prepare(c, func(c *got.Container) {
+		got.Mock[Printer](c, GetPrinter, NoopPrinter{})
+	})
+
+	office := GetOffice.From(ps.Container())
+	if office.Printer != (NoopPrinter{}) {
+		t.Error("expected substituted printer")
+	}
+
+	ps.Reset()
+	rebuilt := GetOffice.From(ps.Container())
+	if rebuilt == office {
+		t.Error("expected office to be rebuilt after reset")
+	}
+	if rebuilt.Printer != (NoopPrinter{}) {
+		t.Error("expected substitution to survive reset")
+	}
+}
+
+func BenchmarkPreparedScope(b *testing.B) {
+	ps := got.Prepare(got.New(), func(c *got.Container) {
+		got.Mock[Printer](c, GetPrinter, NoopPrinter{})
+	})
+	b.ReportAllocs()
+	for b.Loop() {
+		GetOffice.From(ps.Container())
+		ps.Reset()
+	}
+}
@@ -0,0 +1,36 @@
+package got
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// Rand is a Constructor for a randomly seeded random source.
+// Constructors should draw randomness from Rand instead of the global source
+// so tests can mock it with a fixed seed for reproducible results.
+//
+// The returned *rand.Rand is shared by every constructor of the container and is safe for concurrent use.
+// A mocked *rand.Rand is returned as is, so it is only safe for concurrent use if its source is.
+var Rand = Using(func(c *Container) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewPCG(rand.Uint64(), rand.Uint64())})
+})
+
+// lockedSource is a rand.Source safe for concurrent use.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+// FromSeeded builds a constructor for the seed and returns its value resolved in a throwaway scope of the container.
+// Dependencies first resolved by the seeded constructor are cached in the throwaway scope, leaving the container untouched.
+//
+// Use FromSeeded in property tests to get a freshly seeded fake for each iteration.
+func FromSeeded[T any](c *Container, ctFactory func(seed int64) Constructor[T], seed int64) T {
+	return From(c.Scope(), ctFactory(seed))
+}
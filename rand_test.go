@@ -0,0 +1,82 @@
+package got_test
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type Token struct{ Value uint64 }
+
+var GetToken = got.Using(func(c *got.Container) *Token {
+	return &Token{Value: got.Rand.From(c).Uint64()}
+})
+
+func TestRand(t *testing.T) {
+	c1 := got.New()
+	got.Mock(c1, got.Rand, rand.New(rand.NewPCG(1, 2)))
+	c2 := got.New()
+	got.Mock(c2, got.Rand, rand.New(rand.NewPCG(1, 2)))
+
+	if GetToken.From(c1).Value != GetToken.From(c2).Value {
+		t.Error("expected deterministic tokens with the same seed")
+	}
+	if got.Rand.From(got.New()) == got.Rand.From(got.New()) {
+		t.Error("expected a random source per container")
+	}
+}
+
+func TestRandConcurrent(t *testing.T) {
+	c := got.New()
+	r := got.Rand.From(c)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				r.Uint64()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFromSeeded(t *testing.T) {
+	type Fake struct {
+		Seed    int64
+		Counter *Counter
+	}
+	var counterCalls int
+	GetSeedCounter := got.Using(func(c *got.Container) *Counter {
+		counterCalls++
+		return &Counter{}
+	})
+	GetFake := func(seed int64) got.Constructor[*Fake] {
+		return got.Using(func(c *got.Container) *Fake {
+			return &Fake{Seed: seed, Counter: GetSeedCounter.From(c)}
+		})
+	}
+
+	c := got.New()
+	fake1 := got.FromSeeded(c, GetFake, 1)
+	fake2 := got.FromSeeded(c, GetFake, 2)
+
+	if fake1.Seed != 1 || fake2.Seed != 2 {
+		t.Errorf("expected seeds 1 and 2, got %d and %d", fake1.Seed, fake2.Seed)
+	}
+	if fake1.Counter == fake2.Counter {
+		t.Error("expected each seeded resolution to use a throwaway scope")
+	}
+
+	// the base container is untouched
+	counter := GetSeedCounter.From(c)
+	if counterCalls != 3 {
+		t.Errorf("expected dependency to be built in the base container, got %d calls", counterCalls)
+	}
+	if counter == fake1.Counter || counter == fake2.Counter {
+		t.Error("expected base container not to cache values from seeded resolutions")
+	}
+}
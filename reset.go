@@ -0,0 +1,78 @@
+package got
+
+// Clear removes every value from the container cache, including mocked values.
+// Values cached in ancestors of the container are not affected.
+//
+// It is safe to call Clear while other goroutines resolve constructors,
+// they observe either the old or the new state of the cache.
+func (c *Container) Clear() {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clearCache(b.cache())
+	b.managed = nil
+}
+
+// Reset removes the constructor's value from the container cache, including a mocked value,
+// and drops any override set for the constructor in the container,
+// so the next call to From runs the constructor's New method again.
+// Values cached and overrides set in ancestors of the container are not affected.
+// Reset is a no-op if the constructor's value is not cached.
+func Reset[T any](c *Container, ct Constructor[T]) {
+	b := c.base()
+	b.mu.Lock()
+	delete(b.factories, ct)
+	b.mu.Unlock()
+	b.cache().Delete(ct)
+}
+
+// ResetCascade removes the constructor's value from the container cache like Reset,
+// along with the values of every constructor cached in the container that depends on it, directly or transitively,
+// so they are built again with the new value on the next call to From.
+// Values cached in scopes or ancestors of the container are not affected.
+//
+// Use ResetCascade to replace a shared resource, for example a database connection after a reconnect.
+func ResetCascade[T any](c *Container, ct Constructor[T]) {
+	b := c.base()
+	entries := make(map[any]*entry)
+	dependents := make(map[any][]any)
+	b.cache().Range(func(key, value any) bool {
+		e := value.(*entry)
+		entries[key] = e
+		for _, dep := range e.deps {
+			dependents[dep] = append(dependents[dep], key)
+		}
+		return true
+	})
+	Reset(c, ct)
+	stale := []any{ct}
+	seen := map[any]bool{ct: true}
+	for len(stale) > 0 {
+		key := stale[len(stale)-1]
+		stale = stale[:len(stale)-1]
+		for _, dependent := range dependents[key] {
+			if seen[dependent] {
+				continue
+			}
+			seen[dependent] = true
+			stale = append(stale, dependent)
+			compareAndDelete(b.cache(), dependent, entries[dependent])
+		}
+	}
+}
+
+// Reset2 removes the constructor's values from the container cache, including mocked values,
+// so the next call to From2 runs the constructor's New method again.
+// Values cached in ancestors of the container are not affected.
+// Reset2 is a no-op if the constructor's values are not cached.
+func Reset2[T, U any](c *Container, ct Constructor2[T, U]) {
+	c.base().cache().Delete(ct)
+}
+
+// Reset3 removes the constructor's values from the container cache, including mocked values,
+// so the next call to From3 runs the constructor's New method again.
+// Values cached in ancestors of the container are not affected.
+// Reset3 is a no-op if the constructor's values are not cached.
+func Reset3[T, U, V any](c *Container, ct Constructor3[T, U, V]) {
+	c.base().cache().Delete(ct)
+}
@@ -0,0 +1,94 @@
+package got_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestReset(t *testing.T) {
+	c := got.New()
+	counter := GetCounter.From(c)
+	printer := GetPrinter.From(c)
+
+	got.Reset(c, GetCounter)
+	if GetCounter.From(c) == counter {
+		t.Error("expected counter to be rebuilt after reset")
+	}
+	if GetPrinter.From(c) != printer {
+		t.Error("expected other values to be kept")
+	}
+
+	// reset drops mocks
+	got.Mock[Printer](c, GetPrinter, &MockPrinter{})
+	got.Reset(c, GetPrinter)
+	if _, ok := GetPrinter.From(c).(*CapsPrinter); !ok {
+		t.Error("expected real printer after resetting mock")
+	}
+
+	// reset is a no-op for unresolved constructors
+	got.Reset(got.New(), GetCounter)
+}
+
+func TestResetCascade(t *testing.T) {
+	GetDepartment := got.Using(func(c *got.Container) *Office {
+		return GetOffice.From(c)
+	})
+	c := got.New()
+	office := GetOffice.From(c)
+	GetDepartment.From(c)
+	counter := GetCounter.From(c)
+
+	got.ResetCascade(c, GetPrinter)
+	if got.Has(c, GetPrinter) || got.Has(c, GetOffice) || got.Has(c, GetDepartment) {
+		t.Error("expected dependents to be reset transitively")
+	}
+	if GetCounter.From(c) != counter {
+		t.Error("expected unrelated values to be kept")
+	}
+	if GetDepartment.From(c) == office {
+		t.Error("expected dependents to be rebuilt")
+	}
+}
+
+func TestReset2(t *testing.T) {
+	c := got.New()
+	_, err := GetBadOffice.From(c)
+
+	got.Reset2(c, GetBadOffice)
+	if _, err2 := GetBadOffice.From(c); err2 == err {
+		t.Error("expected values to be rebuilt after reset")
+	}
+}
+
+func TestClear(t *testing.T) {
+	c := got.New()
+	office := GetOffice.From(c)
+	got.Mock(c, GetCounter, &Counter{count: 1})
+
+	c.Clear()
+	if GetOffice.From(c) == office {
+		t.Error("expected office to be rebuilt after clear")
+	}
+	if GetCounter.From(c).count != 0 {
+		t.Error("expected mock to be cleared")
+	}
+}
+
+func TestClearConcurrency(t *testing.T) {
+	c := got.New()
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			GetOffice.From(c)
+		}()
+		go func() {
+			defer wg.Done()
+			c.Clear()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,195 @@
+package got
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// types holds the constructors registered with UsingType by type.
+var types sync.Map
+
+// registeredType is a constructor registered with UsingType.
+type registeredType struct {
+	ct any
+	// from resolves the constructor without its type parameter, for Get.
+	from func(*Container) any
+}
+
+// typeKey is the cache key of a value built by a fallback resolver.
+type typeKey struct{ t reflect.Type }
+
+// UsingType creates a new Constructor like Using and registers it as the constructor of type T,
+// so it can be resolved with Resolve without the constructor's variable.
+// UsingType panics if a constructor is already registered for T.
+func UsingType[T any](fn func(*Container) T) Constructor[T] {
+	ct := Using(fn)
+	from := func(c *Container) any { return From(c, ct) }
+	if _, loaded := types.LoadOrStore(reflect.TypeFor[T](), registeredType{ct, from}); loaded {
+		panic(fmt.Sprintf("got: constructor for %s already registered", typeName[T]()))
+	}
+	return ct
+}
+
+// SetFallback sets the function that builds values of types without a registered constructor for Resolve
+// in the container and its scopes.
+// fn returns false if it cannot build a value of the type.
+//
+// Use SetFallback to build values automatically while prototyping, for example with reflection.
+func (c *Container) SetFallback(fn func(t reflect.Type) (any, bool)) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fallback = fn
+}
+
+// Resolve returns an instance of the value of type T from the container.
+// The provider set with Provide for T in the container or its nearest ancestor is used if there is one,
+// then the constructor registered for T with UsingType, which is resolved like From.
+// Otherwise the value is built by the fallback set with SetFallback in the container or its nearest ancestor
+// and cached like a constructor's value.
+// Resolve returns false if T has no provider or registered constructor and the fallback cannot build it.
+//
+// Resolve panics if a provider or the fallback returns a value that is not of type T.
+func Resolve[T any](c *Container) (T, bool) {
+	var zero T
+	t := reflect.TypeFor[T]()
+	if fn := c.provider(t); fn != nil {
+		v, _, err := c.resolveType(t, "provider", func(c *Container) (any, bool) { return fn(c), true })
+		if err != nil {
+			panic(err)
+		}
+		return as[T](v), true
+	}
+	if r, ok := types.Load(t); ok {
+		return From(c, r.(registeredType).ct.(Constructor[T])), true
+	}
+	v, found, err := c.resolveType(t, "fallback", c.fallbackBuild(t))
+	if err != nil {
+		panic(err)
+	}
+	if !found {
+		return zero, false
+	}
+	return as[T](v), true
+}
+
+// ErrNotProvided is the error Get returns when no value of the type can be resolved.
+var ErrNotProvided = errors.New("not provided")
+
+// Provide sets the provider of values of type t for Get and Resolve in the container and its scopes,
+// and drops the value of type t cached in the container, if any.
+// Values built by providers are cached by type like a constructor's value.
+//
+// Provide and Get bypass the type safety of constructors by design, for plugin hosts and other code
+// that only knows the types it needs at runtime. fn must return a value assignable to t.
+func (c *Container) Provide(t reflect.Type, fn func(*Container) any) {
+	b := c.base()
+	b.mu.Lock()
+	if b.providers == nil {
+		b.providers = make(map[reflect.Type]func(*Container) any)
+	}
+	b.providers[t] = fn
+	b.mu.Unlock()
+	b.cache().Delete(typeKey{t})
+}
+
+// Get returns an instance of the value of type t from the container like Resolve,
+// from the provider set with Provide, the constructor registered with UsingType or the fallback set with SetFallback.
+// Get returns an error wrapping ErrNotProvided if none of them can build a value of type t,
+// and an error if the provider or fallback returns a value that is not assignable to t.
+func (c *Container) Get(t reflect.Type) (any, error) {
+	if fn := c.provider(t); fn != nil {
+		v, _, err := c.resolveType(t, "provider", func(c *Container) (any, bool) { return fn(c), true })
+		return v, err
+	}
+	if r, ok := types.Load(t); ok {
+		return r.(registeredType).from(c), nil
+	}
+	v, found, err := c.resolveType(t, "fallback", c.fallbackBuild(t))
+	if err == nil && !found {
+		err = fmt.Errorf("got: %s: %w", t, ErrNotProvided)
+	}
+	return v, err
+}
+
+// resolveType returns the value of type t cached in the container, or builds it with build and caches it.
+// build reports false if it cannot build the value, a nil build cannot build any value.
+// source names build in the error returned when the value is not assignable to t.
+func (c *Container) resolveType(t reflect.Type, source string, build func(*Container) (any, bool)) (any, bool, error) {
+	key := typeKey{t}
+	if c.frame != nil {
+		c.frame.depend(key)
+	}
+	if e, ok := c.load(key); ok {
+		c.hit(e)
+		return e.value, true, nil
+	}
+	if build == nil {
+		return nil, false, nil
+	}
+	name := t.String()
+	if p, err := c.delegate(key); err != nil {
+		return nil, false, fmt.Errorf("got: %s: %w", name, err)
+	} else if p != nil {
+		return p.resolveType(t, source, build)
+	}
+	c.traceMiss(name)
+	e, err := c.once(context.Background(), key, name, func() (*entry, error) {
+		var found bool
+		v, deps := c.construct(key, name, func(c *Container) any {
+			v, ok := build(c)
+			found = ok
+			return v
+		})
+		if !found {
+			return nil, nil
+		}
+		if v != nil && !reflect.TypeOf(v).AssignableTo(t) {
+			return nil, fmt.Errorf("got: %s returned %T for %s", source, v, name)
+		}
+		return c.store(key, &entry{value: v, name: name, deps: deps}), nil
+	})
+	if err != nil || e == nil {
+		return nil, false, err
+	}
+	return e.value, true, nil
+}
+
+// provider returns the provider of type t set in the container or its nearest ancestor.
+func (c *Container) provider(t reflect.Type) func(*Container) any {
+	for b := c.base(); b != nil; b = b.parent {
+		b.mu.Lock()
+		fn := b.providers[t]
+		b.mu.Unlock()
+		if fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
+
+// fallbackBuild returns a function that builds values of type t with the fallback of the container,
+// or nil if there is no fallback.
+func (c *Container) fallbackBuild(t reflect.Type) func(*Container) (any, bool) {
+	fallback := c.fallbackFunc()
+	if fallback == nil {
+		return nil
+	}
+	return func(*Container) (any, bool) { return fallback(t) }
+}
+
+// fallbackFunc returns the fallback set in the container or its nearest ancestor.
+func (c *Container) fallbackFunc() func(reflect.Type) (any, bool) {
+	for b := c.base(); b != nil; b = b.parent {
+		b.mu.Lock()
+		fn := b.fallback
+		b.mu.Unlock()
+		if fn != nil {
+			return fn
+		}
+	}
+	return nil
+}
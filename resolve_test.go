@@ -0,0 +1,167 @@
+package got_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type Clock struct{ name string }
+
+type Greeter struct{ name string }
+
+var GetClock = got.UsingType(func(c *got.Container) *Clock {
+	return &Clock{name: "registered"}
+})
+
+func TestResolve(t *testing.T) {
+	c := got.New()
+	var calls int
+	c.SetFallback(func(t reflect.Type) (any, bool) {
+		calls++
+		if t.Kind() == reflect.Pointer {
+			return reflect.New(t.Elem()).Interface(), true
+		}
+		return nil, false
+	})
+
+	// registered constructors take precedence
+	clock, ok := got.Resolve[*Clock](c)
+	if !ok || clock.name != "registered" || clock != GetClock.From(c) {
+		t.Errorf("expected registered clock, got %v, %v", clock, ok)
+	}
+
+	greeter, ok := got.Resolve[*Greeter](c)
+	if !ok || greeter == nil {
+		t.Fatalf("expected greeter from fallback, got %v, %v", greeter, ok)
+	}
+	if g, _ := got.Resolve[*Greeter](c.Scope()); g != greeter || calls != 1 {
+		t.Error("expected fallback value to be cached")
+	}
+
+	if _, ok := got.Resolve[Greeter](c); ok {
+		t.Error("expected fallback to report unsupported type")
+	}
+	if _, ok := got.Resolve[*Greeter](got.New()); ok {
+		t.Error("expected no value without a fallback")
+	}
+}
+
+func TestUsingTypeDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected duplicate type registration to panic")
+		}
+	}()
+	got.UsingType(func(c *got.Container) *Clock { return &Clock{} })
+}
+
+func TestProvide(t *testing.T) {
+	c := got.New()
+	greeterType := reflect.TypeFor[*Greeter]()
+	var calls int
+	c.Provide(greeterType, func(c *got.Container) any {
+		calls++
+		return &Greeter{name: "provided"}
+	})
+
+	v, err := c.Get(greeterType)
+	if err != nil || v.(*Greeter).name != "provided" {
+		t.Fatalf("expected provided value, got %v, %v", v, err)
+	}
+	if v2, _ := c.Get(greeterType); v2 != v || calls != 1 {
+		t.Error("expected provided value to be cached")
+	}
+
+	// Resolve uses providers and scopes see them
+	if g, ok := got.Resolve[*Greeter](c.Scope()); !ok || g != v {
+		t.Error("expected Resolve to return the provided value")
+	}
+
+	// providers take precedence over registered constructors
+	c.Provide(reflect.TypeFor[*Clock](), func(c *got.Container) any { return &Clock{name: "provided"} })
+	if clock, _ := got.Resolve[*Clock](c); clock.name != "provided" {
+		t.Error("expected provider to take precedence")
+	}
+	if clock, err := got.New().Get(reflect.TypeFor[*Clock]()); err != nil || clock.(*Clock).name != "registered" {
+		t.Error("expected Get to resolve registered constructors")
+	}
+
+	// providing again replaces the cached value
+	c.Provide(greeterType, func(c *got.Container) any { return &Greeter{name: "replaced"} })
+	if v, _ := c.Get(greeterType); v.(*Greeter).name != "replaced" {
+		t.Error("expected new provider to build the value")
+	}
+
+	if _, err := c.Get(reflect.TypeFor[int]()); !errors.Is(err, got.ErrNotProvided) {
+		t.Errorf("expected not provided error, got %v", err)
+	}
+	c.Provide(reflect.TypeFor[string](), func(c *got.Container) any { return 1 })
+	if _, err := c.Get(reflect.TypeFor[string]()); err == nil {
+		t.Error("expected error for value of the wrong type")
+	}
+}
+
+func TestProvideConcurrent(t *testing.T) {
+	c := got.New()
+	greeterType := reflect.TypeFor[*Greeter]()
+	var calls atomic.Int64
+	started, release := make(chan struct{}), make(chan struct{})
+	c.Provide(greeterType, func(c *got.Container) any {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		return &Greeter{}
+	})
+
+	results := make([]any, 5)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], _ = c.Get(greeterType)
+	}()
+	<-started
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = c.Get(greeterType)
+		}()
+	}
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("expected provider to be called once, got %d", calls.Load())
+	}
+	for _, v := range results {
+		if v != results[0] {
+			t.Fatal("expected all callers to get the same value")
+		}
+	}
+}
+
+func TestProvideRestrictScope(t *testing.T) {
+	root := got.New()
+	greeterType := reflect.TypeFor[*Greeter]()
+	root.Provide(greeterType, func(c *got.Container) any { return &Greeter{} })
+	root.Provide(reflect.TypeFor[*Counter](), func(c *got.Container) any { return &Counter{} })
+
+	plugin := root.Scope()
+	got.RestrictScope(plugin, greeterType)
+	v, err := plugin.Get(greeterType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, _ := got.Resolve[*Greeter](root); g != v {
+		t.Error("expected allowed type to be resolved from the parent")
+	}
+	if _, err := plugin.Get(reflect.TypeFor[*Counter]()); !errors.Is(err, got.ErrNotPermitted) {
+		t.Errorf("expected not permitted error, got %v", err)
+	}
+}
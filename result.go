@@ -0,0 +1,45 @@
+package got
+
+// Result holds either a value or an error.
+// The zero Result holds the zero value and no error.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a Result holding the value.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a Result holding the error.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// Unwrap returns the value and error held by the result.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.value, r.err
+}
+
+// IsOk reports whether the result holds no error.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// ValueOr returns the value held by the result, or def if the result holds an error.
+func (r Result[T]) ValueOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.value
+}
+
+// UsingResult creates a new Constructor from a function that accepts a container and returns a Result.
+// The Result is cached whether it holds a value or an error.
+//
+// Use UsingResult as an alternative to Using2 for constructors that may fail.
+// Mock the constructor with Ok or Err to return a mocked value or error.
+func UsingResult[T any](fn func(*Container) Result[T]) Constructor[Result[T]] {
+	return Using(fn)
+}
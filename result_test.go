@@ -0,0 +1,61 @@
+package got_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestUsingResult(t *testing.T) {
+	var calls int
+	GetOkCounter := got.UsingResult(func(c *got.Container) got.Result[*Counter] {
+		calls++
+		return got.Ok(&Counter{count: 1})
+	})
+	errCounter := errors.New("counter unavailable")
+	GetErrCounter := got.UsingResult(func(c *got.Container) got.Result[*Counter] {
+		calls++
+		return got.Err[*Counter](errCounter)
+	})
+
+	c := got.New()
+	ok := GetOkCounter.From(c)
+	counter, err := ok.Unwrap()
+	if !ok.IsOk() || err != nil || counter.count != 1 {
+		t.Errorf("expected ok result, got %v, %v", counter, err)
+	}
+	if ok.ValueOr(nil) != counter {
+		t.Error("expected ValueOr to return the value")
+	}
+
+	failed := GetErrCounter.From(c)
+	if _, err := failed.Unwrap(); failed.IsOk() || err != errCounter {
+		t.Errorf("expected error result, got %v", err)
+	}
+	def := &Counter{}
+	if failed.ValueOr(def) != def {
+		t.Error("expected ValueOr to return the default")
+	}
+
+	// both outcomes are cached
+	GetOkCounter.From(c)
+	GetErrCounter.From(c)
+	if calls != 2 {
+		t.Errorf("expected results to be cached, got %d calls", calls)
+	}
+}
+
+func TestMockResult(t *testing.T) {
+	GetResultPrinter := got.UsingResult(func(c *got.Container) got.Result[Printer] {
+		return got.Ok[Printer](&CapsPrinter{})
+	})
+
+	c := got.New()
+	errPrinter := errors.New("printer unavailable")
+	got.Mock(c, GetResultPrinter, got.Err[Printer](errPrinter))
+
+	if _, err := GetResultPrinter.From(c).Unwrap(); err != errPrinter {
+		t.Errorf("expected mocked error, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package got
+
+// retrier is implemented by two-value constructors whose errors are not cached.
+type retrier interface {
+	retry()
+}
+
+type retry2[T any] struct{ fn func(*Container) (T, error) }
+
+func (ct *retry2[T]) New(c *Container) (T, error) { return ct.fn(c) }
+
+func (ct *retry2[T]) From(c *Container) (T, error) { return From2(c, ct) }
+
+func (ct *retry2[T]) retry() {}
+
+// UsingRetry2 creates a new Constructor2 like Using2 whose values are cached only when the error is nil.
+// When fn returns an error From2 returns it without caching anything, so the next call runs fn again.
+// Callers waiting for a build that fails run fn themselves.
+//
+// Use UsingRetry2 for dependencies that may be temporarily unavailable, for example a database at startup.
+//
+//	var GetDB = got.UsingRetry2(func(c *got.Container) (*sql.DB, error) {
+//		return connect(GetConfig.From(c))
+//	})
+func UsingRetry2[T any](fn func(*Container) (T, error)) Constructor2[T, error] {
+	return &retry2[T]{fn}
+}
@@ -0,0 +1,37 @@
+package got_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestUsingRetry2(t *testing.T) {
+	errDown := errors.New("database down")
+	var calls int
+	GetConn := got.UsingRetry2(func(c *got.Container) (*Counter, error) {
+		calls++
+		if calls < 3 {
+			return nil, errDown
+		}
+		return &Counter{count: calls}, nil
+	})
+
+	c := got.New()
+	for range 2 {
+		if _, err := GetConn.From(c); err != errDown {
+			t.Errorf("expected error, got %v", err)
+		}
+		if got.Has2(c, GetConn) {
+			t.Error("expected error not to be cached")
+		}
+	}
+	conn, err := GetConn.From(c)
+	if err != nil || conn.count != 3 {
+		t.Fatalf("expected value after retries, got %v, %v", conn, err)
+	}
+	if again, _ := GetConn.From(c); again != conn || calls != 3 {
+		t.Error("expected successful value to be cached")
+	}
+}
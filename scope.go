@@ -0,0 +1,102 @@
+package got
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Scope creates a child container.
+// Values cached in the container are shared with the child,
+// while values resolved or mocked through the child are cached in the child only.
+// The child is configured with the options of the container.
+func (c *Container) Scope() *Container {
+	b := c.base()
+	s := &Container{parent: b, opts: b.opts}
+	s.debug.Store(b.debug.Load())
+	s.tracer.Store(b.tracer.Load())
+	return s
+}
+
+// Clone creates an independent copy of the container with the values currently cached in it,
+// including mocked values, and its decorators, overrides and hooks.
+// Mocking, resetting or resolving constructors in the clone does not affect the container and vice versa.
+// The clone shares the parent and the options of the container.
+//
+// Cached values are copied by reference, the values themselves are shared.
+// Close functions are not copied, they remain registered with the container,
+// and FromManaged in the clone does not register copied values, they are closed by the container only.
+func (c *Container) Clone() *Container {
+	b := c.base()
+	clone := &Container{parent: b.parent, opts: b.opts, meta: b.meta, ctx: b.ctx}
+	clone.managed = make(map[any]bool)
+	b.cache().Range(func(key, e any) bool {
+		clone.cache().Store(key, e)
+		clone.managed[e] = true
+		return true
+	})
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.decorators != nil {
+		clone.decorators = make(map[any][]any, len(b.decorators))
+		for key, decorators := range b.decorators {
+			clone.decorators[key] = slices.Clone(decorators)
+		}
+	}
+	clone.factories = maps.Clone(b.factories)
+	clone.flags = maps.Clone(b.flags)
+	clone.allowed = maps.Clone(b.allowed)
+	clone.observers = slices.Clone(b.observers)
+	clone.warners = slices.Clone(b.warners)
+	clone.before = slices.Clone(b.before)
+	clone.after = slices.Clone(b.after)
+	clone.fallback = b.fallback
+	clone.providers = maps.Clone(b.providers)
+	clone.effects = maps.Clone(b.effects)
+	clone.maxDepth = b.maxDepth
+	clone.debug.Store(b.debug.Load())
+	clone.tracer.Store(b.tracer.Load())
+	return clone
+}
+
+// ErrNotPermitted is the error From panics with when resolving a constructor that is not allowed in a restricted scope.
+var ErrNotPermitted = errors.New("not permitted in this scope")
+
+// RestrictScope restricts the constructors that can be resolved in the scope to the allowed constructors.
+// Allowed constructors are resolved from the parent of the scope so their values are shared,
+// while resolving any other constructor that is not cached in the scope panics with ErrNotPermitted.
+// A reflect.Type in allowed permits values of that type resolved with Resolve or Get,
+// Get returns an error wrapping ErrNotPermitted instead of panicking.
+//
+// Use RestrictScope to limit the services reachable from a sandboxed scope, for example a plugin.
+func RestrictScope(scope *Container, allowed ...any) {
+	b := scope.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.allowed = make(map[any]bool, len(allowed))
+	for _, ct := range allowed {
+		if t, ok := ct.(reflect.Type); ok {
+			ct = typeKey{t}
+		}
+		b.allowed[ct] = true
+	}
+}
+
+// delegate returns the container to resolve the constructor from when the container is a restricted scope.
+func (c *Container) delegate(key any) (*Container, error) {
+	b := c.base()
+	b.mu.Lock()
+	allowed := b.allowed
+	b.mu.Unlock()
+	if allowed == nil {
+		return nil, nil
+	}
+	if !allowed[key] {
+		return nil, ErrNotPermitted
+	}
+	if b.parent == nil {
+		return nil, nil
+	}
+	return b.parent.view(c.res, c.frame), nil
+}
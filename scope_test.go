@@ -0,0 +1,83 @@
+package got_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestRestrictScope(t *testing.T) {
+	root := got.New()
+	plugin := root.Scope()
+	got.RestrictScope(plugin, GetPrinter)
+
+	printer := GetPrinter.From(plugin)
+	if printer != GetPrinter.From(root) {
+		t.Error("expected allowed constructor to be resolved from the parent")
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, got.ErrNotPermitted) {
+			t.Errorf("expected not permitted panic, got %v", err)
+		}
+	}()
+	GetOffice.From(plugin)
+}
+
+func TestScope(t *testing.T) {
+	root := got.New()
+	printer := GetPrinter.From(root)
+
+	scope := root.Scope()
+	if GetPrinter.From(scope) != printer {
+		t.Error("expected scope to share values cached in the parent")
+	}
+
+	counter := GetCounter.From(scope)
+	if GetCounter.From(scope) != counter {
+		t.Error("expected scope to cache its own values")
+	}
+	if GetCounter.From(root) == counter {
+		t.Error("expected parent not to be mutated by scope resolutions")
+	}
+
+	other := root.Scope()
+	if GetCounter.From(other) == counter {
+		t.Error("expected sibling scopes not to share values")
+	}
+
+	got.Mock[Printer](scope, GetPrinter, &MockPrinter{})
+	if GetPrinter.From(root) != printer {
+		t.Error("expected parent not to be mutated by scope mocks")
+	}
+}
+
+func TestClone(t *testing.T) {
+	c := got.New()
+	office := GetOffice.From(c)
+
+	clone := c.Clone()
+	if GetOffice.From(clone) != office {
+		t.Error("expected clone to share cached values")
+	}
+
+	got.Mock[Printer](clone, GetPrinter, &MockPrinter{})
+	got.Reset(clone, GetOffice)
+	if _, ok := GetOffice.From(clone).Printer.(*MockPrinter); !ok {
+		t.Error("expected clone to use its own mock")
+	}
+	if GetOffice.From(c) != office {
+		t.Error("expected reset in clone not to affect the container")
+	}
+	if _, ok := GetPrinter.From(c).(*CapsPrinter); !ok {
+		t.Error("expected mock in clone not to affect the container")
+	}
+
+	got.Reset(c, GetCounter)
+	counter := GetCounter.From(c)
+	if GetCounter.From(clone) == counter {
+		t.Error("expected values resolved in the container not to affect the clone")
+	}
+}
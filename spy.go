@@ -0,0 +1,52 @@
+package got
+
+import (
+	"slices"
+	"sync"
+)
+
+// SpyHandle records method calls made on the real value of a spied constructor.
+type SpyHandle[T any] struct {
+	c  *Container
+	ct Constructor[T]
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// Spy returns a handle that records method calls made on the real value built by the constructor in the container.
+// Install a wrapper with Decorate that forwards each method call to the real value and records it with Record.
+//
+//	spy := got.Spy(c, GetPrinter)
+//	got.Decorate(c, GetPrinter, func(c *got.Container, p Printer) Printer {
+//		return &RecordingPrinter{p, spy.Record}
+//	})
+func Spy[T any](c *Container, ct Constructor[T]) *SpyHandle[T] {
+	return &SpyHandle[T]{c: c, ct: ct}
+}
+
+// Value resolves the real value of the spied constructor from the container like From.
+func (h *SpyHandle[T]) Value() T {
+	return From(h.c, h.ct)
+}
+
+// Record records a call of method on the real value, it is called by the wrapper installed with Decorate.
+func (h *SpyHandle[T]) Record(method string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, method)
+}
+
+// CallCount returns the number of recorded method calls.
+func (h *SpyHandle[T]) CallCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.calls)
+}
+
+// Calls returns the names of the recorded method calls in the order they were made.
+func (h *SpyHandle[T]) Calls() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.calls)
+}
@@ -0,0 +1,48 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+type RecordingPrinter struct {
+	Printer
+	record func(method string)
+}
+
+func (p *RecordingPrinter) Print(s string) string {
+	p.record("Print")
+	return p.Printer.Print(s)
+}
+
+func TestSpy(t *testing.T) {
+	c := got.New()
+	spy := got.Spy(c, GetPrinter)
+	got.Decorate(c, GetPrinter, func(c *got.Container, p Printer) Printer {
+		return &RecordingPrinter{p, spy.Record}
+	})
+
+	office := GetOffice.From(c)
+	if spy.Value() != office.Printer {
+		t.Error("expected spy to resolve the wrapped real value")
+	}
+	if got := office.Printer.Print("hello"); got != "HELLO" {
+		t.Errorf("expected real printer output %q, got %q", "HELLO", got)
+	}
+	office.Printer.Print("world")
+
+	if spy.CallCount() != 2 {
+		t.Errorf("expected 2 calls, got %d", spy.CallCount())
+	}
+	if calls := spy.Calls(); calls[0] != "Print" || calls[1] != "Print" {
+		t.Errorf("expected Print calls, got %v", calls)
+	}
+
+	// other containers are not spied
+	other := got.New()
+	GetPrinter.From(other).Print("hello")
+	if spy.CallCount() != 2 {
+		t.Errorf("expected spy to ignore other containers, got %d calls", spy.CallCount())
+	}
+}
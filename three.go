@@ -0,0 +1,70 @@
+package got
+
+import "fmt"
+
+// Constructor3 is implemented by any type that has
+// a New method that accepts a container and returns three values,
+// and a convenience From method that accepts a container and returns the values from the container.
+//
+// Use Using3 to create a new Constructor3.
+type Constructor3[T, U, V any] interface {
+	New(*Container) (T, U, V)
+	From(*Container) (T, U, V)
+}
+
+type constructor3[T, U, V any] struct{ fn func(*Container) (T, U, V) }
+
+func (ct *constructor3[T, U, V]) New(c *Container) (T, U, V) {
+	return ct.fn(c)
+}
+
+func (ct *constructor3[T, U, V]) From(c *Container) (T, U, V) { return From3(c, ct) }
+
+// Using3 creates a new Constructor3 from a function that accepts a container and returns three values.
+//
+// Use Using3 when a constructor returns three values for example a client, a cleanup function and an error.
+func Using3[T, U, V any](fn func(*Container) (T, U, V)) Constructor3[T, U, V] {
+	return &constructor3[T, U, V]{fn}
+}
+
+// From3 returns an instance of a constructor's value from the container.
+// Like From, New runs once even when many goroutines call From3 at the same time.
+// The constructor's New method is called the first time and the return values are cached.
+// Future calls will return the cached values.
+//
+// The values are cached only after New returns normally.
+// If New panics nothing is cached and the next call runs New again.
+func From3[T, U, V any](c *Container, ct Constructor3[T, U, V]) (T, U, V) {
+	if c.frame != nil {
+		c.frame.depend(ct)
+	}
+	if e, ok := c.load(ct); ok {
+		c.traceHit(e)
+		f3 := e.value.(from3[T, U, V])
+		return f3.v1, f3.v2, f3.v3
+	}
+	if p, err := c.delegate(ct); err != nil {
+		panic(fmt.Errorf("got: %s: %w", typeName3[T, U, V](), err))
+	} else if p != nil {
+		return From3(p, ct)
+	}
+	name := typeName3[T, U, V]()
+	c.traceMiss(name)
+	e := c.onceWait(ct, name, func() (*entry, error) {
+		v, deps := c.construct(ct, name, func(c *Container) any {
+			v1, v2, v3 := ct.New(c)
+			return from3[T, U, V]{v1, v2, v3}
+		})
+		return c.store(ct, &entry{value: v, name: name, deps: deps}), nil
+	})
+	f3 := e.value.(from3[T, U, V])
+	return f3.v1, f3.v2, f3.v3
+}
+
+type from3[T, U, V any] struct {
+	v1 T
+	v2 U
+	v3 V
+}
+
+func (f3 from3[T, U, V]) values() any { return []any{f3.v1, f3.v2, f3.v3} }
@@ -0,0 +1,29 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestUsing3(t *testing.T) {
+	var calls int
+	GetClient := got.Using3(func(c *got.Container) (*Counter, func(), error) {
+		calls++
+		return &Counter{count: calls}, func() {}, nil
+	})
+
+	c := got.New()
+	client, cleanup, err := GetClient.From(c)
+	client2, cleanup2, err2 := got.From3(c, GetClient)
+
+	if client != client2 || err != err2 {
+		t.Error("expected values to be cached")
+	}
+	if cleanup == nil || cleanup2 == nil {
+		t.Error("expected cleanup to be cached")
+	}
+	if calls != 1 {
+		t.Errorf("expected constructor to run once, got %d", calls)
+	}
+}
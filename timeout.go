@@ -0,0 +1,146 @@
+package got
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// FromTimeout returns an instance of a constructor's value from the container like From,
+// and an error wrapping context.DeadlineExceeded if building the value takes longer than d.
+// The value is built in a new goroutine, on timeout the construction is abandoned
+// and its value is discarded when it returns, so nothing is cached and a later call builds the value again.
+// The context returned by Container.Context in the constructor is canceled once the construction is abandoned
+// or the container is closed, a constructor that watches it can stop early.
+// Otherwise an abandoned construction keeps running until the constructor returns, Close only waits for it
+// if the container was created with WithCloseTimeout,
+// and the functions registered with OnClose by the abandoned constructor are called once it returns.
+//
+// Concurrent callers share a single construction and its timeout error, each caller waits at most d for it.
+// A panic in the constructor is propagated to the caller unless the construction was abandoned.
+func FromTimeout[T any](c *Container, ct Constructor[T], d time.Duration) (T, error) {
+	var zero T
+	if c.frame != nil {
+		c.frame.depend(ct)
+	}
+	if e, owner := c.loadOwner(ct); e != nil {
+		v, ok := e.get()
+		if ok {
+			c.hit(e)
+			return as[T](v), nil
+		}
+		// the value was held by weak reference and reclaimed, build it again.
+		compareAndDelete(owner.cache(), ct, e)
+	}
+	if p, err := c.delegate(ct); err != nil {
+		panic(fmt.Errorf("got: %s: %w", typeName[T](), err))
+	} else if p != nil {
+		return FromTimeout(p, ct, d)
+	}
+	name := typeName[T]()
+	c.traceMiss(name)
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	e, err := c.once(ctx, ct, name, func() (*entry, error) {
+		a := newAttempt(c)
+		go a.run(c, func(c *Container) *entry {
+			v, deps := c.construct(ct, name, func(c *Container) any {
+				return newDecorated(c, ct)
+			})
+			return &entry{value: as[T](v), name: name, deps: deps}
+		}, func(e *entry) *entry {
+			if _, ok := ct.(*transient[T]); ok {
+				return e
+			}
+			return c.store(ct, e)
+		})
+		if e := a.wait(ctx); e != nil {
+			return e, nil
+		}
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		return zero, fmt.Errorf("got: %s: construction timed out after %v: %w", name, d, err)
+	}
+	v, _ := e.get()
+	return as[T](v), nil
+}
+
+// attempt is a construction running in its own goroutine that can be abandoned.
+type attempt struct {
+	done chan struct{}
+	// ctx is canceled once the attempt is abandoned or the container is closed.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// list tracks the attempt until it ends.
+	list *closerList
+	// closers holds the functions registered with OnClose by the constructor.
+	closers closerList
+
+	mu        sync.Mutex
+	abandoned bool
+	e         *entry
+	panicked  any
+}
+
+// newAttempt returns an attempt to build a value for c, tracked by the container until it ends.
+// Its context derives from the context of c, so an attempt started by another attempt is canceled with it.
+func newAttempt(c *Container) *attempt {
+	ctx, cancel := context.WithCancel(c.Context())
+	a := &attempt{done: make(chan struct{}), ctx: ctx, cancel: cancel, list: c.base().list()}
+	a.list.track(a.done)
+	return a
+}
+
+// run builds the entry through a view of c, and caches it with store and registers the functions
+// the constructor registered with OnClose with c unless the attempt was abandoned.
+// The value of an abandoned attempt is discarded, so its functions are called once build returns.
+func (a *attempt) run(c *Container, build func(*Container) *entry, store func(*entry) *entry) {
+	defer func() {
+		a.cancel()
+		a.list.untrack(a.done)
+		close(a.done)
+	}()
+	var e *entry
+	defer func() {
+		p := recover()
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if a.abandoned {
+			if err := a.closers.close(); err != nil {
+				log.Printf("got: close abandoned construction: %v", err)
+			}
+			return
+		}
+		if a.panicked = p; p == nil {
+			a.e = store(e)
+		}
+		c.addClosers(a.closers.fns...)
+	}()
+	res := &resolution{ctx: a.ctx}
+	if c.res != nil {
+		res.errs, res.strict = c.res.errs, c.res.strict
+	}
+	e = build(&Container{owner: c.base(), res: res, frame: c.frame, closeTo: &a.closers})
+}
+
+// wait returns the entry cached by the attempt, or abandons the attempt and returns nil if ctx is done first.
+// A panic in the attempt is propagated to the caller.
+func (a *attempt) wait(ctx context.Context) *entry {
+	select {
+	case <-a.done:
+	case <-ctx.Done():
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.panicked != nil {
+		panic(a.panicked)
+	}
+	if a.e == nil {
+		a.abandoned = true
+		a.cancel()
+	}
+	return a.e
+}
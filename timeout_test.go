@@ -0,0 +1,203 @@
+package got_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eriicafes/got"
+)
+
+func TestFromTimeout(t *testing.T) {
+	c := got.New()
+	office, err := got.FromTimeout(c, GetOffice, time.Second)
+	if err != nil || office != GetOffice.From(c) {
+		t.Errorf("expected cached office, got %v, %v", office, err)
+	}
+}
+
+func TestFromTimeoutExceeded(t *testing.T) {
+	var builds atomic.Int32
+	release, closed := make(chan struct{}), make(chan struct{})
+	GetHanging := got.Using(func(c *got.Container) *Counter {
+		if builds.Add(1) == 1 {
+			<-release
+			got.OnClose(c, func() error {
+				close(closed)
+				return nil
+			})
+		}
+		return &Counter{}
+	})
+
+	c := got.New()
+	if _, err := got.FromTimeout(c, GetHanging, time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+
+	// the abandoned value is not cached and released once the constructor returns
+	close(release)
+	<-closed
+	if got.Has(c, GetHanging) {
+		t.Error("expected abandoned value not to be cached")
+	}
+	if counter, err := got.FromTimeout(c, GetHanging, time.Second); err != nil || counter == nil || builds.Load() != 2 {
+		t.Errorf("expected retry to build value, got %v, %v", counter, err)
+	}
+}
+
+func TestFromTimeoutClose(t *testing.T) {
+	GetHung := got.Using(func(c *got.Container) *Counter {
+		var never chan struct{}
+		<-never
+		return &Counter{}
+	})
+
+	c := got.New()
+	var closes int
+	got.OnClose(c, func() error {
+		closes++
+		return nil
+	})
+	if _, err := got.FromTimeout(c, GetHung, time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	// Close does not wait for a constructor that never returns
+	if err := c.Close(); err != nil || closes != 1 {
+		t.Errorf("expected close functions to be called, got %d calls, %v", closes, err)
+	}
+
+	// nor longer than the close timeout
+	c = got.New(got.WithCloseTimeout(time.Millisecond))
+	if _, err := got.FromTimeout(c, GetHung, time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("expected close to give up waiting, got %v", err)
+	}
+}
+
+func TestFromTimeoutCancel(t *testing.T) {
+	stopped := make(chan struct{})
+	GetDialer := got.Using(func(c *got.Container) *Counter {
+		<-c.Context().Done()
+		close(stopped)
+		return &Counter{}
+	})
+
+	c := got.New()
+	if _, err := got.FromTimeout(c, GetDialer, time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	// the context of an abandoned construction is canceled
+	<-stopped
+}
+
+func TestFromTimeoutCloseCancel(t *testing.T) {
+	var running atomic.Int32
+	started := make(chan struct{})
+	GetDialer := got.Using(func(c *got.Container) *Counter {
+		running.Add(1)
+		defer running.Add(-1)
+		close(started)
+		<-c.Context().Done()
+		return &Counter{}
+	})
+
+	c := got.New(got.WithCloseTimeout(time.Second))
+	errs := make(chan error, 1)
+	go func() {
+		_, err := got.FromTimeout(c, GetDialer, time.Minute)
+		errs <- err
+	}()
+	<-started
+	// Close cancels the construction and waits for it to end
+	if err := c.Close(); err != nil {
+		t.Errorf("expected close to succeed, got %v", err)
+	}
+	if n := running.Load(); n != 0 {
+		t.Errorf("expected no construction running after close, got %d", n)
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("expected construction to return, got %v", err)
+	}
+
+	// a closed container gets a new context
+	if err := c.Context().Err(); err != nil {
+		t.Errorf("expected new context after close, got %v", err)
+	}
+}
+
+func TestFromTimeoutShared(t *testing.T) {
+	var builds atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	GetSlow := got.Using(func(c *got.Container) *Counter {
+		builds.Add(1)
+		close(started)
+		<-release
+		return &Counter{}
+	})
+
+	c := got.New()
+	results := make([]*Counter, 5)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter, err := got.FromTimeout(c, GetSlow, time.Hour)
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			results[i] = counter
+		}()
+	}
+	<-started
+	close(release)
+	wg.Wait()
+	if n := builds.Load(); n != 1 {
+		t.Errorf("expected concurrent callers to share one construction, ran %d times", n)
+	}
+	for _, counter := range results {
+		if counter == nil || counter != results[0] {
+			t.Fatal("expected all callers to get the same value")
+		}
+	}
+}
+
+func TestFromTimeoutPanic(t *testing.T) {
+	GetPanicking := got.Using(func(c *got.Container) *Counter {
+		panic("boom")
+	})
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected panic to propagate, got %v", r)
+		}
+	}()
+	got.FromTimeout(got.New(), GetPanicking, time.Second)
+}
+
+func TestFromTimeoutWithFrom(t *testing.T) {
+	var builds atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	GetHanging := got.Using(func(c *got.Container) *Counter {
+		if builds.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		return &Counter{}
+	})
+	defer close(release)
+
+	c := got.New()
+	go got.FromTimeout(c, GetHanging, time.Millisecond)
+	<-started
+
+	// From waits for the shared construction and builds the value itself after the timeout
+	if counter := GetHanging.From(c); counter == nil {
+		t.Error("expected From to build the value after the timeout")
+	}
+}
@@ -0,0 +1,23 @@
+package got
+
+// Token identifies the instance of a constructor's value cached in a container.
+// Tokens are comparable, the token of a value stays the same across cache hits
+// and changes once the value is reset and built again or mocked.
+type Token struct{ e *entry }
+
+// FromToken returns an instance of a constructor's value from the container like From,
+// along with the token of the instance.
+// A value that is not cached, such as the value of a transient constructor, gets a new token on every call.
+//
+// Use FromToken in caching layers built on top of the container to tell when a value they derive from was replaced.
+func FromToken[T any](c *Container, ct Constructor[T]) (T, Token) {
+	// the token is taken from the entry that holds the returned value, so a concurrent Reset or Mock
+	// cannot pair the value with the token of another instance.
+	// Cache entries are never reused, so a value built again gets a new entry.
+	var r resolved
+	v := from(c, ct, &r)
+	if r.e == nil {
+		r.e = &entry{}
+	}
+	return v, Token{r.e}
+}
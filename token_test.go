@@ -0,0 +1,63 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestFromToken(t *testing.T) {
+	c := got.New()
+	counter, token := got.FromToken(c, GetCounter)
+	if v, again := got.FromToken(c, GetCounter); v != counter || again != token {
+		t.Error("expected token to be stable across cache hits")
+	}
+	if _, scoped := got.FromToken(c.Scope(), GetCounter); scoped != token {
+		t.Error("expected scope to share the token of the parent value")
+	}
+
+	got.Reset(c, GetCounter)
+	rebuilt, next := got.FromToken(c, GetCounter)
+	if rebuilt == counter || next == token {
+		t.Error("expected token to change after reset and rebuild")
+	}
+	got.Mock(c, GetCounter, &Counter{})
+	if _, mocked := got.FromToken(c, GetCounter); mocked == next {
+		t.Error("expected token to change after mock")
+	}
+
+	GetTransient := got.Transient(func(*got.Container) *Counter { return &Counter{} })
+	_, a := got.FromToken(c, GetTransient)
+	_, b := got.FromToken(c, GetTransient)
+	if a == b {
+		t.Error("expected transient values to get a new token on every call")
+	}
+}
+
+// writerFunc is an io.Writer that calls the function.
+type writerFunc func(p []byte) (int, error)
+
+func (fn writerFunc) Write(p []byte) (int, error) { return fn(p) }
+
+func TestFromTokenResetWhileResolving(t *testing.T) {
+	c := got.New()
+	GetCounter.From(c)
+	scope := c.Scope()
+	// the trace of the scope runs while its call to FromToken resolves the value,
+	// the value is reset and built again in the parent meanwhile.
+	reset := false
+	scope.Trace(writerFunc(func(p []byte) (int, error) {
+		if !reset {
+			reset = true
+			got.Reset(c, GetCounter)
+			GetCounter.From(c)
+		}
+		return len(p), nil
+	}))
+
+	v, token := got.FromToken(scope, GetCounter)
+	rebuilt, next := got.FromToken(c, GetCounter)
+	if v == rebuilt || token == next {
+		t.Error("expected token of the instance that was returned")
+	}
+}
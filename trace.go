@@ -0,0 +1,157 @@
+package got
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Span describes the construction of a value by a traced constructor.
+type Span struct {
+	// Name is the name of the traced constructor.
+	Name string
+	// Parent is the span of the nearest traced constructor that depends on this one,
+	// or nil if there is none.
+	Parent *Span
+}
+
+// UsingTraced creates a new Constructor like Using that calls the hooks registered
+// with BeforeResolve and AfterResolve around building its value.
+// Hooks are called on cache misses only, with a span named name.
+//
+// Spans nest following the dependencies between traced constructors,
+// so concurrent resolutions in the same container do not interleave their spans.
+func UsingTraced[T any](name string, fn func(*Container) T) Constructor[T] {
+	return Using(func(c *Container) T {
+		s := &Span{Name: name}
+		if f := c.frame; f != nil {
+			s.Parent = f.parent.traced()
+			f.mu.Lock()
+			f.span = s
+			f.mu.Unlock()
+		}
+		for _, hook := range c.hooks(func(b *Container) []func(*Span) { return b.before }) {
+			hook(s)
+		}
+		defer func() {
+			for _, hook := range c.hooks(func(b *Container) []func(*Span) { return b.after }) {
+				hook(s)
+			}
+		}()
+		return fn(c)
+	})
+}
+
+// traced returns the span of the nearest traced construction from the frame, or nil if there is none.
+func (f *frame) traced() *Span {
+	for ; f != nil; f = f.parent {
+		f.mu.Lock()
+		s := f.span
+		f.mu.Unlock()
+		if s != nil {
+			return s
+		}
+	}
+	return nil
+}
+
+// BeforeResolve registers fn to be called before a constructor created with UsingTraced builds its value
+// in the container or its scopes.
+func (c *Container) BeforeResolve(fn func(s *Span)) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.before = append(b.before, fn)
+}
+
+// AfterResolve registers fn to be called after a constructor created with UsingTraced builds its value
+// in the container or its scopes, including when the constructor panics.
+func (c *Container) AfterResolve(fn func(s *Span)) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.after = append(b.after, fn)
+}
+
+// hooks returns the hooks selected from the container and its ancestors, hooks of the container first.
+func (c *Container) hooks(sel func(b *Container) []func(*Span)) []func(*Span) {
+	var hooks []func(*Span)
+	for b := c.base(); b != nil; b = b.parent {
+		b.mu.Lock()
+		hooks = append(hooks, sel(b)...)
+		b.mu.Unlock()
+	}
+	return hooks
+}
+
+// tracer writes the events of a container traced with Trace.
+type tracer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (t *tracer) printf(format string, args ...any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.w, format+"\n", args...)
+}
+
+// traceHit writes the event of resolving the cached entry e, if the container is traced.
+func (c *Container) traceHit(e *entry) {
+	if t := c.base().tracer.Load(); t != nil {
+		t.printf("resolve constructor(%s) hit", e.name)
+	}
+}
+
+// traceMiss writes the event of resolving a constructor named name that is not cached, if the container is traced.
+func (c *Container) traceMiss(name string) {
+	if t := c.base().tracer.Load(); t != nil {
+		t.printf("resolve constructor(%s) miss -> build", name)
+	}
+}
+
+// Trace writes a line to w for every event of the container and of scopes created afterwards:
+// resolutions with whether the value was cached, values stored, mocked and reset, and clears.
+//
+//	resolve constructor(*main.Office) miss -> build
+//	resolve constructor(main.Printer) miss -> build
+//	store constructor(main.Printer)
+//	store constructor(*main.Office)
+//	mock constructor(main.Printer)
+//	resolve constructor(main.Printer) hit
+//
+// Lines are written one at a time, so w need not be safe for concurrent use.
+// Trace(nil) stops tracing, a container that is not traced only checks whether it is.
+// Tracing does not change how values are resolved.
+func (c *Container) Trace(w io.Writer) {
+	if w == nil {
+		c.base().tracer.Store(nil)
+		return
+	}
+	c.base().tracer.Store(&tracer{w: w})
+}
+
+// resolveObservers returns the observers registered with OnResolve in the container and its ancestors.
+func (c *Container) resolveObservers() []func(any, time.Duration) {
+	var observers []func(any, time.Duration)
+	for b := c.base(); b != nil; b = b.parent {
+		b.mu.Lock()
+		observers = append(observers, b.observers...)
+		b.mu.Unlock()
+	}
+	return observers
+}
+
+// OnResolve registers fn to be called after a constructor builds a value in the container or its scopes,
+// with the constructor and the time it took to build the value including its dependencies.
+// fn is not called when a value is returned from the cache, a transient constructor calls it on every build.
+//
+// Register observers before resolving constructors concurrently.
+// fn is called on the goroutine that built the value and must be safe for concurrent use.
+func (c *Container) OnResolve(fn func(ct any, dur time.Duration)) {
+	b := c.base()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.observers = append(b.observers, fn)
+}
@@ -0,0 +1,172 @@
+package got_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eriicafes/got"
+)
+
+var (
+	GetTracedPrinter = got.UsingTraced("printer", func(c *got.Container) Printer {
+		return &CapsPrinter{}
+	})
+	GetTracedOffice = got.UsingTraced("office", func(c *got.Container) *Office {
+		return &Office{Printer: GetTracedPrinter.From(c)}
+	})
+	GetTracedBuilding = got.UsingTraced("building", func(c *got.Container) []*Office {
+		return []*Office{GetOffice.From(c), GetTracedOffice.From(c)}
+	})
+)
+
+func spanPath(s *got.Span) string {
+	var names []string
+	for ; s != nil; s = s.Parent {
+		names = append([]string{s.Name}, names...)
+	}
+	return strings.Join(names, "/")
+}
+
+func TestUsingTraced(t *testing.T) {
+	c := got.New()
+	var events []string
+	c.BeforeResolve(func(s *got.Span) { events = append(events, "before "+spanPath(s)) })
+	c.AfterResolve(func(s *got.Span) { events = append(events, "after "+spanPath(s)) })
+
+	GetTracedBuilding.From(c)
+	GetTracedOffice.From(c)
+	expected := []string{
+		"before building",
+		"before building/office",
+		"before building/office/printer",
+		"after building/office/printer",
+		"after building/office",
+		"after building",
+	}
+	if strings.Join(events, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected events %v, got %v", expected, events)
+	}
+}
+
+func TestUsingTracedConcurrency(t *testing.T) {
+	c := got.New()
+	var mu sync.Mutex
+	paths := map[string]bool{}
+	c.BeforeResolve(func(s *got.Span) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths[spanPath(s)] = true
+	})
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			GetTracedBuilding.From(c.Scope())
+		}()
+	}
+	wg.Wait()
+	for path := range paths {
+		if path != "building" && path != "building/office" && path != "building/office/printer" {
+			t.Errorf("unexpected span path %q", path)
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	var buf strings.Builder
+	c := got.New()
+	c.Trace(&buf)
+
+	GetOffice.From(c)
+	got.Mock[Printer](c, GetPrinter, &MockPrinter{})
+	GetPrinter.From(c.Scope())
+	got.Reset(c, GetPrinter)
+	c.Clear()
+
+	want := `resolve constructor(*got_test.Office) miss -> build
+resolve constructor(got_test.Printer) miss -> build
+store constructor(got_test.Printer)
+store constructor(*got_test.Office)
+mock constructor(got_test.Printer)
+resolve constructor(got_test.Printer) hit
+reset constructor(got_test.Printer)
+clear
+`
+	if buf.String() != want {
+		t.Errorf("expected trace:\n%s\ngot:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	c.Trace(nil)
+	GetOffice.From(c)
+	if buf.Len() != 0 {
+		t.Errorf("expected no trace after disabling, got %q", buf.String())
+	}
+}
+
+func TestTraceCtxAndTimeout(t *testing.T) {
+	var buf strings.Builder
+	c := got.New()
+	c.Trace(&buf)
+	GetClient := got.UsingCtx(func(ctx context.Context, c *got.Container) *Counter {
+		return &Counter{}
+	})
+
+	GetClient.From(context.Background(), c)
+	GetClient.From(context.Background(), c)
+	got.FromTimeout(c, GetPrinter, time.Second)
+	got.FromTimeout(c, GetPrinter, time.Second)
+
+	want := `resolve constructor(*got_test.Counter) miss -> build
+store constructor(*got_test.Counter)
+resolve constructor(*got_test.Counter) hit
+resolve constructor(got_test.Printer) miss -> build
+store constructor(got_test.Printer)
+resolve constructor(got_test.Printer) hit
+`
+	if buf.String() != want {
+		t.Errorf("expected trace:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestOnResolve(t *testing.T) {
+	var elapsed time.Duration
+	GetSlow := got.Using(func(c *got.Container) *Counter {
+		start := time.Now()
+		GetPrinter.From(c)
+		elapsed = time.Since(start)
+		return &Counter{}
+	})
+
+	c := got.New()
+	var mu sync.Mutex
+	durations := map[any]time.Duration{}
+	calls := 0
+	c.OnResolve(func(ct any, dur time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		durations[ct] = dur
+		calls++
+	})
+
+	GetOffice.From(c)
+	GetOffice.From(c)
+	GetSlow.From(c.Scope())
+	if calls != 3 {
+		t.Errorf("expected observer to fire once per build, got %d calls", calls)
+	}
+	if _, ok := durations[GetPrinter]; !ok {
+		t.Error("expected observer to fire for dependencies")
+	}
+	if durations[GetSlow] < elapsed {
+		t.Errorf("expected build duration of at least %v, got %v", elapsed, durations[GetSlow])
+	}
+	if durations[GetOffice] < durations[GetPrinter] {
+		t.Error("expected build duration to include dependencies")
+	}
+}
@@ -0,0 +1,17 @@
+package got
+
+type transient[T any] struct{ fn func(*Container) T }
+
+func (ct *transient[T]) New(c *Container) T { return ct.fn(c) }
+
+func (ct *transient[T]) From(c *Container) T { return From(c, ct) }
+
+func (ct *transient[T]) Unwrap() func(*Container) T { return ct.fn }
+
+// Transient creates a new Constructor from a function that accepts a container and returns a value.
+// Its value is never cached, From calls the function every time.
+//
+// Mocking a transient constructor still overrides it, From returns the mocked value instead of calling the function.
+func Transient[T any](fn func(*Container) T) Constructor[T] {
+	return &transient[T]{fn}
+}
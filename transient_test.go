@@ -0,0 +1,33 @@
+package got_test
+
+import (
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestTransient(t *testing.T) {
+	GetBuffer := got.Transient(func(c *got.Container) *Counter {
+		return &Counter{}
+	})
+	GetHolder := got.Using(func(c *got.Container) *[2]*Counter {
+		return &[2]*Counter{GetBuffer.From(c), GetBuffer.From(c)}
+	})
+
+	c := got.New()
+	if GetBuffer.From(c) == GetBuffer.From(c) {
+		t.Error("expected transient to return a new instance on every From")
+	}
+	if got.From(c, GetBuffer) == got.From(c, GetBuffer) {
+		t.Error("expected transient to return a new instance on every got.From")
+	}
+	if holder := GetHolder.From(c); holder[0] == holder[1] {
+		t.Error("expected transient dependencies to be new instances")
+	}
+
+	mocked := &Counter{count: 1}
+	got.Mock(c, GetBuffer, mocked)
+	if GetBuffer.From(c) != mocked {
+		t.Error("expected mock to override transient")
+	}
+}
@@ -0,0 +1,148 @@
+package got
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ResolveError records the constructor whose error was returned by Try2 or MustFrom2,
+// or by From2 in a container created with WithErrorChain.
+type ResolveError struct {
+	// Constructor is the name of the type built by the constructor.
+	Constructor string
+	// Chain is the names of the constructors that led to the failing constructor, ending with it.
+	// Chain is set only by containers created with WithErrorChain.
+	Chain []string
+	Err   error
+}
+
+func (e *ResolveError) Error() string {
+	if len(e.Chain) > 0 {
+		return fmt.Sprintf("got: %s: %v", strings.Join(e.Chain, " -> "), e.Err)
+	}
+	return fmt.Sprintf("got: %s: %v", e.Constructor, e.Err)
+}
+
+func (e *ResolveError) Unwrap() error { return e.Err }
+
+// Try2 returns an instance of a constructor's values from the container like From2,
+// wrapping a non-nil error in a *ResolveError that names the constructor.
+// The cached error is not modified, only the returned error is wrapped.
+//
+// Use Try2 inside constructors so an error from a deep dependency reports each constructor on its path.
+func Try2[T any](c *Container, ct Constructor2[T, error]) (T, error) {
+	v, err := From2(c, ct)
+	if err != nil {
+		return v, &ResolveError{Constructor: typeName[T](), Err: err}
+	}
+	return v, nil
+}
+
+// MustFrom2 returns the value of a constructor from the container like From2,
+// and panics with a *ResolveError that names the constructor if the error is not nil.
+//
+// Use MustFrom2 at startup where a failed dependency is unrecoverable.
+func MustFrom2[T any](c *Container, ct Constructor2[T, error]) T {
+	v, err := Try2(c, ct)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// chainError wraps a non-nil error in f2 in a *ResolveError with the chain of constructions leading to it,
+// unless the error already carries a chain or cannot hold a *ResolveError.
+func chainError[T, U any](c *Container, f2 from2[T, U]) from2[T, U] {
+	err, ok := any(f2.v2).(error)
+	if !ok || err == nil {
+		return f2
+	}
+	if re := (*ResolveError)(nil); errors.As(err, &re) && len(re.Chain) > 0 {
+		return f2
+	}
+	re := &ResolveError{Constructor: typeName[T](), Chain: c.frame.names(typeName2[T, U]()), Err: err}
+	if v2, ok := any(re).(U); ok {
+		f2.v2 = v2
+	}
+	return f2
+}
+
+// strictError carries the error that stops a resolution started with FromStrict2.
+type strictError struct{ err error }
+
+// shortCircuit panics with a strictError if the resolution of the container is strict and v is a non-nil error.
+func (c *Container) shortCircuit(v any) {
+	if c.res == nil || !c.res.strict {
+		return
+	}
+	if err, ok := v.(error); ok && err != nil {
+		panic(strictError{err})
+	}
+}
+
+// FromStrict2 returns an instance of a constructor's value from the container like From2,
+// but stops the resolution at the first non-nil error returned by a two-value constructor resolved in it.
+// The constructors depending on the failing one do not run past the call that resolves it and are not cached,
+// and FromStrict2 returns the zero value of T with the error.
+// The failing constructor's values are cached as usual, including errors that were already cached.
+//
+// Use FromStrict2 when constructors assume their dependencies succeeded, so an error is reported instead of a nil value.
+func FromStrict2[T any](c *Container, ct Constructor2[T, error]) (v T, err error) {
+	res := &resolution{strict: true}
+	if c.res != nil {
+		res.errs, res.ctx = c.res.errs, c.res.ctx
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s, ok := r.(strictError)
+			if !ok {
+				panic(r)
+			}
+			var zero T
+			v, err = zero, s.err
+		}
+	}()
+	return From2(c.view(res, c.frame), ct)
+}
+
+// FromCollect2 returns an instance of a constructor's value from the container like From2,
+// along with every non-nil error returned by two-value constructors built during this resolution.
+// Errors are collected in the order the constructors returned.
+//
+// Constructors that were already cached are not built again and their errors are not collected,
+// except for the error of the constructor being resolved which is always included.
+func FromCollect2[T any](c *Container, ct Constructor2[T, error]) (T, []error) {
+	errs := &errorList{}
+	res := &resolution{errs: errs}
+	if c.res != nil {
+		res.ctx = c.res.ctx
+	}
+	v, err := From2(c.view(res, c.frame), ct)
+	collected := errs.list()
+	if err != nil && len(collected) == 0 {
+		// the constructor was already cached so nothing was built during this resolution.
+		collected = append(collected, err)
+	}
+	return v, collected
+}
+
+// errorList is a list of errors that is safe for concurrent use.
+type errorList struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (l *errorList) add(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = append(l.errs, err)
+}
+
+func (l *errorList) list() []error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.errs)
+}
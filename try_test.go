@@ -0,0 +1,212 @@
+package got_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestTry2(t *testing.T) {
+	type Database struct{}
+	type Store struct{ DB *Database }
+	errDown := errors.New("database down")
+	GetDB := got.Using2(func(c *got.Container) (*Database, error) {
+		return nil, errDown
+	})
+	GetStore := got.Using2(func(c *got.Container) (*Store, error) {
+		db, err := got.Try2(c, GetDB)
+		if err != nil {
+			return nil, err
+		}
+		return &Store{db}, nil
+	})
+
+	c := got.New()
+	_, err := got.Try2(c, GetStore)
+	if !errors.Is(err, errDown) {
+		t.Fatalf("expected wrapped error to match, got %v", err)
+	}
+	var re *got.ResolveError
+	if !errors.As(err, &re) || re.Constructor != "*got_test.Store" {
+		t.Errorf("expected resolve error for store, got %v", err)
+	}
+	if want := "got: *got_test.Store: got: *got_test.Database: database down"; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+
+	// the cached error is not wrapped
+	if _, err := GetDB.From(c); err != errDown {
+		t.Errorf("expected cached error to be unchanged, got %v", err)
+	}
+
+	// nil errors are not wrapped
+	GetOK := got.Using2(func(c *got.Container) (*Database, error) {
+		return &Database{}, nil
+	})
+	if _, err := got.Try2(c, GetOK); err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+}
+
+func TestMustFrom2(t *testing.T) {
+	c := got.New()
+	office := &Office{}
+	GetOffice2 := got.Using2(func(c *got.Container) (*Office, error) {
+		return office, nil
+	})
+	if got.MustFrom2(c, GetOffice2) != office {
+		t.Error("expected value")
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		var re *got.ResolveError
+		if !errors.As(err, &re) || re.Constructor != "*got_test.Office" {
+			t.Errorf("expected panic with resolve error, got %v", err)
+		}
+		if want := "got: *got_test.Office: failed to create office"; err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+	}()
+	got.MustFrom2(c, GetBadOffice)
+}
+
+func TestWithErrorChain(t *testing.T) {
+	type Database struct{}
+	type Store struct{ DB *Database }
+	type App struct{ Store *Store }
+	errDown := errors.New("connection refused")
+	GetDB := got.Using2(func(c *got.Container) (*Database, error) {
+		return nil, errDown
+	})
+	GetStore := got.Using2(func(c *got.Container) (*Store, error) {
+		db, err := GetDB.From(c)
+		return &Store{db}, err
+	})
+	GetApp := got.Using2(func(c *got.Container) (*App, error) {
+		store, err := GetStore.From(c)
+		return &App{store}, err
+	})
+
+	c := got.New(got.WithErrorChain())
+	_, err := GetApp.From(c)
+	var re *got.ResolveError
+	if !errors.As(err, &re) || !errors.Is(err, errDown) {
+		t.Fatalf("expected resolve error wrapping the cause, got %v", err)
+	}
+	chain := []string{"(*got_test.App, error)", "(*got_test.Store, error)", "(*got_test.Database, error)"}
+	if !slices.Equal(re.Chain, chain) || re.Constructor != "*got_test.Database" {
+		t.Errorf("expected chain %v, got %v", chain, re.Chain)
+	}
+	want := "got: (*got_test.App, error) -> (*got_test.Store, error) -> (*got_test.Database, error): connection refused"
+	if err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+
+	// errors are not wrapped without the option
+	if _, err := GetApp.From(got.New()); err != errDown {
+		t.Errorf("expected unwrapped error, got %v", err)
+	}
+}
+
+func TestFromStrict2(t *testing.T) {
+	type Database struct{ name string }
+	type Store struct{ DB *Database }
+	errDown := errors.New("database down")
+	GetDB := got.Using2(func(c *got.Container) (*Database, error) {
+		return nil, errDown
+	})
+	var ran bool
+	GetStore := got.Using(func(c *got.Container) *Store {
+		db, _ := GetDB.From(c)
+		ran = true
+		return &Store{DB: db}
+	})
+	GetApp := got.Using2(func(c *got.Container) (*Store, error) {
+		return GetStore.From(c), nil
+	})
+
+	c := got.New()
+	if _, err := got.FromStrict2(c, GetApp); err != errDown {
+		t.Fatalf("expected dependency error, got %v", err)
+	}
+	if ran {
+		t.Error("expected dependent not to run past the failing dependency")
+	}
+	if got.Has(c, GetStore) || got.Has2(c, GetApp) {
+		t.Error("expected dependents not to be cached")
+	}
+
+	// cached errors also stop the resolution
+	if _, err := got.FromStrict2(c, GetApp); err != errDown {
+		t.Errorf("expected cached dependency error, got %v", err)
+	}
+
+	// other resolutions are not affected
+	if store, err := GetApp.From(c); err != nil || store.DB != nil || !ran {
+		t.Error("expected From2 to run dependents with the failed value")
+	}
+	got.Reset2(c, GetApp)
+	got.Mock2(c, GetDB, &Database{name: "mock"}, nil)
+	got.Reset(c, GetStore)
+	if store, err := got.FromStrict2(c, GetApp); err != nil || store.DB.name != "mock" {
+		t.Errorf("expected resolution to succeed, got %v", err)
+	}
+
+	// other panics are not recovered
+	GetPanic := got.Using2(func(c *got.Container) (int, error) { panic("boom") })
+	defer func() {
+		if recover() != "boom" {
+			t.Error("expected panic to propagate")
+		}
+	}()
+	got.FromStrict2(got.New(), GetPanic)
+}
+
+func TestFromCollect2(t *testing.T) {
+	errDB := fmt.Errorf("db unavailable")
+	errCache := fmt.Errorf("cache unavailable")
+
+	GetDB := got.Using2(func(c *got.Container) (*Counter, error) {
+		return nil, errDB
+	})
+	GetCache := got.Using2(func(c *got.Container) (*Counter, error) {
+		return nil, errCache
+	})
+	GetService := got.Using2(func(c *got.Container) (*Office, error) {
+		if _, err := GetDB.From(c); err != nil {
+			return nil, fmt.Errorf("service: %w", err)
+		}
+		return &Office{}, nil
+	})
+	GetApp := got.Using2(func(c *got.Container) (*Office, error) {
+		_, err1 := GetService.From(c)
+		_, err2 := GetCache.From(c)
+		return nil, errors.Join(err1, err2)
+	})
+
+	c := got.New()
+	_, errs := got.FromCollect2(c, GetApp)
+
+	if len(errs) != 4 {
+		t.Fatalf("expected 4 errors, got %d: %v", len(errs), errs)
+	}
+	if errs[0] != errDB {
+		t.Errorf("expected db error first, got %v", errs[0])
+	}
+	if !errors.Is(errs[1], errDB) {
+		t.Errorf("expected service error wrapping db error, got %v", errs[1])
+	}
+	if errs[2] != errCache {
+		t.Errorf("expected cache error, got %v", errs[2])
+	}
+
+	// cached constructors are not built again
+	_, errs = got.FromCollect2(c, GetApp)
+	if len(errs) != 1 || !errors.Is(errs[0], errCache) {
+		t.Errorf("expected only the cached error, got %v", errs)
+	}
+}
@@ -0,0 +1,76 @@
+package got
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FromUntil2 returns an instance of a constructor's value from the container like From2,
+// retrying the constructor every interval while it returns an error until it succeeds or ctx is done.
+// Only a successful result is cached, errors are never cached.
+// An error cached by an earlier call to From2 is retried like any other error.
+// If ctx is done before the constructor succeeds FromUntil2 returns an error wrapping ctx.Err() and the last error.
+//
+// Concurrent callers share a single retry loop.
+// Use FromUntil2 at startup to wait for a dependency that may not be ready yet, for example a database.
+func FromUntil2[T any](ctx context.Context, c *Container, ct Constructor2[T, error], interval time.Duration) (T, error) {
+	var zero T
+	if c.frame != nil {
+		c.frame.depend(ct)
+	}
+	name := typeName2[T, error]()
+	for {
+		if e, owner := c.loadOwner(ct); e != nil {
+			f2 := e.value.(from2[T, error])
+			if f2.v2 == nil || e.mock {
+				c.traceHit(e)
+				return f2.v1, f2.v2
+			}
+			compareAndDelete(owner.cache(), ct, e)
+			continue
+		}
+		if p, err := c.delegate(ct); err != nil {
+			panic(fmt.Errorf("got: %s: %w", name, err))
+		} else if p != nil {
+			return FromUntil2(ctx, p, ct, interval)
+		}
+		c.traceMiss(name)
+		// a failed retry loop caches nothing so waiting callers run their own loop with their own ctx.
+		var lastErr error
+		e, err := c.once(ctx, ct, name, func() (*entry, error) {
+			e, err := retry(ctx, c, ct, name, interval)
+			lastErr = err
+			return e, nil
+		})
+		if err != nil {
+			return zero, fmt.Errorf("got: %s: %w", name, err)
+		}
+		if lastErr != nil {
+			return zero, lastErr
+		}
+		f2 := e.value.(from2[T, error])
+		return f2.v1, f2.v2
+	}
+}
+
+// retry runs the constructor until it succeeds or ctx is done, caching the successful result.
+func retry[T any](ctx context.Context, c *Container, ct Constructor2[T, error], name string, interval time.Duration) (*entry, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		v, deps := c.construct(ct, name, func(c *Container) any {
+			v, err := ct.New(c)
+			return from2[T, error]{v, err}
+		})
+		err := v.(from2[T, error]).v2
+		if err == nil {
+			return c.store(ct, &entry{value: v, name: name, deps: deps}), nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("got: %s: %w: %w", name, ctx.Err(), err)
+		}
+	}
+}
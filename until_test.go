@@ -0,0 +1,118 @@
+package got_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eriicafes/got"
+)
+
+func TestFromUntil2(t *testing.T) {
+	var attempts atomic.Int64
+	errNotReady := errors.New("database not ready")
+	GetDB := got.Using2(func(c *got.Container) (*Counter, error) {
+		n := attempts.Add(1)
+		if n <= 2 {
+			return nil, errNotReady
+		}
+		return &Counter{count: int(n)}, nil
+	})
+
+	c := got.New()
+	var wg sync.WaitGroup
+	results := make([]*Counter, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			db, err := got.FromUntil2(context.Background(), c, GetDB, time.Millisecond)
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			results[i] = db
+		}()
+	}
+	wg.Wait()
+
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts shared by all callers, got %d", attempts.Load())
+	}
+	for _, db := range results {
+		if db == nil || db != results[0] {
+			t.Fatal("expected all callers to get the same value")
+		}
+	}
+	if db, err := GetDB.From(c); db != results[0] || err != nil {
+		t.Error("expected successful value to be cached")
+	}
+}
+
+func TestFromUntil2Cancelled(t *testing.T) {
+	errNotReady := errors.New("database not ready")
+	GetDB := got.Using2(func(c *got.Container) (*Counter, error) {
+		return nil, errNotReady
+	})
+
+	c := got.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err := got.FromUntil2(ctx, c, GetDB, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errNotReady) {
+		t.Errorf("expected deadline and last error, got %v", err)
+	}
+}
+
+func TestFromUntil2RetriesCachedError(t *testing.T) {
+	var attempts atomic.Int64
+	errNotReady := errors.New("database not ready")
+	GetDB := got.Using2(func(c *got.Container) (*Counter, error) {
+		if attempts.Add(1) == 1 {
+			return nil, errNotReady
+		}
+		return &Counter{}, nil
+	})
+
+	c := got.New()
+	if _, err := GetDB.From(c); !errors.Is(err, errNotReady) {
+		t.Fatalf("expected first attempt to fail, got %v", err)
+	}
+	db, err := got.FromUntil2(context.Background(), c, GetDB, time.Millisecond)
+	if db == nil || err != nil {
+		t.Fatalf("expected cached error to be retried, got %v, %v", db, err)
+	}
+	if v, _ := GetDB.From(c); v != db {
+		t.Error("expected successful value to replace the cached error")
+	}
+}
+
+func TestFromUntil2RestrictScope(t *testing.T) {
+	GetDB := got.Using2(func(c *got.Container) (*Counter, error) {
+		return &Counter{}, nil
+	})
+	root := got.New()
+	plugin := root.Scope()
+	got.RestrictScope(plugin, GetDB)
+
+	db, err := got.FromUntil2(context.Background(), plugin, GetDB, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := GetDB.From(root); v != db {
+		t.Error("expected allowed constructor to be resolved from the parent")
+	}
+
+	GetOther := got.Using2(func(c *got.Container) (*Counter, error) {
+		return &Counter{}, nil
+	})
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, got.ErrNotPermitted) {
+			t.Errorf("expected not permitted panic, got %v", err)
+		}
+	}()
+	got.FromUntil2(context.Background(), plugin, GetOther, time.Millisecond)
+}
@@ -0,0 +1,45 @@
+package got
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate runs each root in a clone of the container and returns the first error.
+// A root resolves the constructors the application needs at startup, a panic in a root is returned as an error,
+// including circular dependency errors. Values built by a root are closed with the clone before the next root runs,
+// so validation leaves no values, mocks or close functions behind in the container.
+// Hooks registered with the container are copied to the clones and observe the resolutions.
+// Values already cached in the container are copied too and are not built again, validate before resolving them.
+//
+// Use Validate as a smoke test of the wiring of an application, for example in CI.
+//
+//	err := got.Validate(c, func(c *got.Container) {
+//		GetServer.From(c)
+//		got.MustFrom2(c, GetDB)
+//	})
+func Validate(c *Container, roots ...func(*Container)) error {
+	for _, root := range roots {
+		clone := c.Clone()
+		err := validate(clone, root)
+		if err := errors.Join(err, clone.Close()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate runs root in the container and returns the value it panics with as an error.
+func validate(c *Container, root func(*Container)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("got: panic: %v", r)
+			}
+		}
+	}()
+	root(c)
+	return nil
+}
@@ -0,0 +1,73 @@
+package got_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestValidate(t *testing.T) {
+	c := got.New()
+	if err := got.Validate(c, func(c *got.Container) { GetOffice.From(c) }); err != nil {
+		t.Errorf("expected valid graph, got %v", err)
+	}
+	if got.Has(c, GetOffice) {
+		t.Error("expected validation not to cache values in the container")
+	}
+
+	var closed bool
+	GetClosing := got.Using(func(c *got.Container) int {
+		got.OnClose(c, func() error {
+			closed = true
+			return nil
+		})
+		return 1
+	})
+	got.Validate(c, func(c *got.Container) { GetClosing.From(c) })
+	if !closed {
+		t.Error("expected values built during validation to be closed")
+	}
+
+	err := got.Validate(c,
+		func(c *got.Container) { GetPrinter.From(c) },
+		func(c *got.Container) { got.MustFrom2(c, GetBadOffice) },
+		func(c *got.Container) { panic("not reached") },
+	)
+	if re := (*got.ResolveError)(nil); !errors.As(err, &re) {
+		t.Errorf("expected first error to be returned, got %v", err)
+	}
+
+	err = got.Validate(c, func(c *got.Container) { panic("boom") })
+	if err == nil || err.Error() != "got: panic: boom" {
+		t.Errorf("expected panic as error, got %v", err)
+	}
+
+	// circular dependencies are reported
+	var GetA, GetB got.Constructor[int]
+	GetA = got.Using(func(c *got.Container) int { return GetB.From(c) })
+	GetB = got.Using(func(c *got.Container) int { return GetA.From(c) })
+	if err := got.Validate(c, func(c *got.Container) { GetA.From(c) }); !errors.Is(err, got.ErrCircularDependency) {
+		t.Errorf("expected circular dependency error, got %v", err)
+	}
+}
+
+func TestValidateManaged(t *testing.T) {
+	var closed []string
+	GetManaged := got.Using(func(c *got.Container) *Resource {
+		return &Resource{name: "db", closed: &closed}
+	})
+
+	c := got.New()
+	got.FromManaged(c, GetManaged)
+	if err := got.Validate(c, func(c *got.Container) { got.FromManaged(c, GetManaged) }); err != nil {
+		t.Fatal(err)
+	}
+	if len(closed) != 0 {
+		t.Errorf("expected validation not to close values of the container, closed %v", closed)
+	}
+	c.Close()
+	if len(closed) != 1 {
+		t.Errorf("expected container to close its value once, closed %v", closed)
+	}
+}
@@ -0,0 +1,43 @@
+package got
+
+import "sync"
+
+// Warm calls each resolver with the container in order, so the constructors they resolve are built immediately
+// instead of on first use. A resolver typically calls a constructor's From method.
+//
+// Resolvers run left to right on the calling goroutine, each returns before the next one is called.
+// A constructor's dependencies are built before the constructor itself,
+// so a dependency shared by several resolvers is built by the first resolver that needs it.
+// If a resolver panics the remaining resolvers are not called.
+//
+// Use Warm at startup to surface construction errors and panics at boot rather than on the first request.
+func (c *Container) Warm(resolvers ...func(*Container)) {
+	for _, resolve := range resolvers {
+		resolve(c)
+	}
+}
+
+// Do calls fn if and only if Do is called for the first time with key in the container,
+// like sync.Once scoped to the container. Concurrent calls with the same key wait for the first one to return.
+// If fn panics Do considers it returned, later calls with key do not call fn.
+//
+// Effects are tracked apart from the cache, so Clear and Reset do not make Do call fn again.
+// Scopes track their own effects, clones share the effects started in the container before cloning.
+//
+// Use Do for side effects that are not values, for example registering metrics once per container.
+//
+//	c.Do("metrics", func() { prometheus.MustRegister(requests) })
+func (c *Container) Do(key any, fn func()) {
+	b := c.base()
+	b.mu.Lock()
+	once, ok := b.effects[key]
+	if !ok {
+		if b.effects == nil {
+			b.effects = make(map[any]*sync.Once)
+		}
+		once = &sync.Once{}
+		b.effects[key] = once
+	}
+	b.mu.Unlock()
+	once.Do(fn)
+}
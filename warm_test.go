@@ -0,0 +1,68 @@
+package got_test
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eriicafes/got"
+)
+
+func TestWarm(t *testing.T) {
+	c := got.New()
+	var order []string
+	c.Warm(
+		func(c *got.Container) { GetOffice.From(c); order = append(order, "office") },
+		func(c *got.Container) { GetCounter.From(c); order = append(order, "counter") },
+	)
+	if !got.Has(c, GetOffice) || !got.Has(c, GetPrinter) || !got.Has(c, GetCounter) {
+		t.Error("expected warmed constructors and their dependencies to be cached")
+	}
+	if !slices.Equal(order, []string{"office", "counter"}) {
+		t.Errorf("expected resolvers to run in order, got %v", order)
+	}
+
+	// remaining resolvers are not called after a panic
+	other := got.New()
+	func() {
+		defer func() { recover() }()
+		other.Warm(
+			func(c *got.Container) { panic("boom") },
+			func(c *got.Container) { GetCounter.From(c) },
+		)
+	}()
+	if got.Has(other, GetCounter) {
+		t.Error("expected resolvers after a panic not to run")
+	}
+}
+
+func TestDo(t *testing.T) {
+	c := got.New()
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Do("seed", func() { calls.Add(1) })
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("expected one call, got %d", calls.Load())
+	}
+
+	c.Clear()
+	c.Do("seed", func() { calls.Add(1) })
+	c.Clone().Do("seed", func() { calls.Add(1) })
+	if calls.Load() != 1 {
+		t.Error("expected effects to survive clear and be shared with clones")
+	}
+
+	c.Do("other", func() { calls.Add(1) })
+	c.Scope().Do("seed", func() { calls.Add(1) })
+	if calls.Load() != 3 {
+		t.Errorf("expected other keys and scopes to run their effects, got %d calls", calls.Load())
+	}
+}
@@ -0,0 +1,94 @@
+package got
+
+import (
+	"fmt"
+	"weak"
+)
+
+// weakValue is the cached value of a constructor created with UsingWeak.
+type weakValue[T any] struct{ p weak.Pointer[T] }
+
+func (w weakValue[T]) strong() (any, bool) {
+	if v := w.p.Value(); v != nil {
+		return v, true
+	}
+	return nil, false
+}
+
+// weakly is implemented by constructors whose values are cached by weak reference.
+type weakly[T any] interface {
+	resolveWeak(c *Container, r *resolved) T
+}
+
+type weakConstructor[T any] struct{ fn func(*Container) *T }
+
+func (ct *weakConstructor[T]) New(c *Container) *T { return ct.fn(c) }
+
+func (ct *weakConstructor[T]) From(c *Container) *T { return From(c, ct) }
+
+func (ct *weakConstructor[T]) Unwrap() func(*Container) *T { return ct.fn }
+
+// UsingWeak creates a new Constructor whose value is cached by weak reference.
+// The garbage collector may reclaim the value once nothing else refers to it,
+// the next call to From then runs the constructor again.
+//
+// Use UsingWeak for large values that are only used occasionally and are cheap enough to rebuild.
+// Since a value may be rebuilt after a garbage collection, values returned by different calls to From
+// are only guaranteed to be the same while the value is in use.
+// Mocked values are cached by strong reference like the values of other constructors.
+//
+// UsingWeak takes a function returning a pointer, because only pointers can be referenced weakly.
+func UsingWeak[T any](fn func(*Container) *T) Constructor[*T] {
+	return &weakConstructor[T]{fn}
+}
+
+func (ct *weakConstructor[T]) resolveWeak(c *Container, r *resolved) *T {
+	name := typeName[*T]()
+	build := func(c *Container) any { return newDecorated(c, Constructor[*T](ct)) }
+	for {
+		e, owner := c.loadOwner(ct)
+		if e == nil {
+			if p, err := c.delegate(ct); err != nil {
+				panic(fmt.Errorf("got: %s: %w", name, err))
+			} else if p != nil {
+				return ct.resolveWeak(p, r)
+			}
+			c.traceMiss(name)
+			var v *T
+			var mine *entry
+			e = c.onceWait(ct, name, func() (*entry, error) {
+				x, deps := c.construct(ct, name, build)
+				v = as[*T](x)
+				mine = &entry{value: weakValue[T]{weak.Make(v)}, name: name, weak: true, deps: deps}
+				stored := c.store(ct, mine)
+				if r != nil && stored == mine {
+					r.built = true
+				}
+				return stored, nil
+			})
+			// the built value is only returned if it was cached, a mock installed meanwhile wins.
+			// v keeps it alive until then.
+			if e == mine {
+				if r != nil {
+					r.e = e
+				}
+				return v
+			}
+			owner = c.base()
+		}
+		if r != nil {
+			r.e = e
+		}
+		w, ok := e.value.(weakValue[T])
+		if !ok {
+			c.hit(e)
+			return as[*T](e.value)
+		}
+		if v := w.p.Value(); v != nil {
+			c.hit(e)
+			return v
+		}
+		// the value was reclaimed, drop the entry so the next iteration builds it again.
+		compareAndDelete(owner.cache(), ct, e)
+	}
+}
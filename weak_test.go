@@ -0,0 +1,92 @@
+package got_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/eriicafes/got"
+)
+
+type Blob struct{ data []byte }
+
+func TestUsingWeak(t *testing.T) {
+	var builds int
+	GetBlob := got.UsingWeak(func(c *got.Container) *Blob {
+		builds++
+		return &Blob{data: make([]byte, 1<<20)}
+	})
+
+	c := got.New()
+	blob := GetBlob.From(c)
+	if GetBlob.From(c) != blob || builds != 1 {
+		t.Error("expected value to be cached while in use")
+	}
+	runtime.KeepAlive(blob)
+
+	blob = nil
+	runtime.GC()
+	if GetBlob.From(c) == nil || builds != 2 {
+		t.Errorf("expected value to be rebuilt after it was collected, got %d builds", builds)
+	}
+
+	// mocked values are kept by strong reference
+	got.Mock(c, GetBlob, &Blob{})
+	runtime.GC()
+	if GetBlob.From(c).data != nil {
+		t.Error("expected mocked value")
+	}
+	got.Reset(c, GetBlob)
+	if GetBlob.From(c).data == nil {
+		t.Error("expected value to be built after reset")
+	}
+}
+
+func TestUsingWeakEntries(t *testing.T) {
+	GetBlob := got.UsingWeak(func(c *got.Container) *Blob {
+		return &Blob{data: make([]byte, 8)}
+	})
+	c := got.New()
+	blob := GetBlob.From(c)
+
+	var values []any
+	got.ForEachResolved(c, func(v any) { values = append(values, v) })
+	if len(values) != 1 || values[0] != blob {
+		t.Errorf("expected the value held by weak reference, got %v", values)
+	}
+
+	GetHolder := got.Using(func(c *got.Container) *Office {
+		GetBlob.From(c)
+		return &Office{}
+	})
+	_, deps := got.FromWithDeps(c, GetHolder)
+	if deps["*got_test.Blob"] != blob {
+		t.Errorf("expected the value held by weak reference in deps, got %v", deps)
+	}
+	if v, err := got.FromTimeout(c, GetBlob, time.Second); err != nil || v != blob {
+		t.Errorf("expected FromTimeout to return the cached value, got %v, %v", v, err)
+	}
+	runtime.KeepAlive(blob)
+}
+
+func TestUsingWeakMockDuringBuild(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	GetBlob := got.UsingWeak(func(c *got.Container) *Blob {
+		close(started)
+		<-release
+		return &Blob{}
+	})
+	c := got.New()
+	mocked := &Blob{data: []byte("mock")}
+	done := make(chan *Blob)
+	go func() { done <- GetBlob.From(c) }()
+	<-started
+	got.Mock(c, GetBlob, mocked)
+	close(release)
+	if v := <-done; v != mocked {
+		t.Error("expected mock installed during the build to win")
+	}
+	if GetBlob.From(c) != mocked {
+		t.Error("expected mock to stay cached")
+	}
+}